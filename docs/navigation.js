@@ -18,17 +18,24 @@ export const navigation = [
             { title: 'Custom Spans in Tracing', href: '/docs/advanced-guide/custom-spans-in-tracing' },
             { title: 'HTTP Communication', href: '/docs/advanced-guide/http-communication' },
             { title: 'HTTP Authentication', href: '/docs/advanced-guide/http-authentication' },
+            { title: 'HTTP Routing', href: '/docs/advanced-guide/http-routing' },
+            { title: 'HTTP Responses', href: '/docs/advanced-guide/http-responses' },
+            { title: 'Request Validation', href: '/docs/advanced-guide/request-validation' },
+            { title: 'Rate Limiting', href: '/docs/advanced-guide/rate-limiting' },
+            { title: 'HTTP Caching', href: '/docs/advanced-guide/http-caching' },
+            { title: 'OpenAPI Documentation', href: '/docs/advanced-guide/openapi-documentation' },
             { title: 'Circuit Breaker Support', href: '/docs/advanced-guide/circuit-breaker' },
             { title: 'Monitoring Service Health', href: '/docs/advanced-guide/monitoring-service-health' },
             { title: 'Handling Data Migrations', href: '/docs/advanced-guide/handling-data-migrations' },
             { title: 'Writing gRPC Server', href: '/docs/advanced-guide/grpc' },
             { title: 'Using Pub/Sub', href: '/docs/advanced-guide/using-publisher-subscriber' },
+            { title: 'Using Cron Jobs', href: '/docs/advanced-guide/using-cron' },
+            { title: 'WebSockets', href: '/docs/advanced-guide/websockets' },
             { title: 'Injecting Databases', href: '/docs/advanced-guide/injecting-databases-drivers' },
             { title: 'Dealing with Datasources', href: '/docs/advanced-guide/dealing-with-datasources' },
             // { title: 'Dealing with Remote Files', href: '/docs/advanced-guide/remote-files' },
             // { title: 'Supporting OAuth', href: '/docs/advanced-guide/oauth' },
             // { title: 'Creating a Static File Server', href: '/docs/advanced-guide/static-file-server' },
-            // { title: 'WebSockets', href: '/docs/advanced-guide/websockets' },
         ],
     },
     {
@@ -0,0 +1,355 @@
+package gofr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// CacheConfig configures the caching of the responses of a route. The successful responses are given a strong ETag
+// computed from their body, for the requests with a matching If-None-Match header to be responded to with the status
+// code 304 Not Modified.
+type CacheConfig struct {
+	// Control is the Cache-Control header of the responses, like "public, max-age=60", set unless the handler sets it.
+	Control string
+
+	// TTL is the time for which the responses are stored by the app, to be served without calling the handler. The
+	// responses are not stored when it is 0, while they are still validated using their ETag. The responses setting
+	// cookies or whose Cache-Control is private or no-store are never stored, nor the responses to the requests with an
+	// Authorization header unless it is one of Vary.
+	TTL time.Duration
+
+	// Vary are the request headers the responses depend on, like Accept-Language or Authorization. The responses are
+	// stored for each of their values, and the headers are listed in the Vary header of the responses.
+	Vary []string
+
+	// Tags are the tags of the stored responses, which are purged using Context.InvalidateCache. They can refer to the
+	// path parameters of the route, like user:{id}.
+	Tags []string
+}
+
+// Cache enables the caching of the responses to the GET and HEAD requests of the route, e.g.
+//
+//	app.GET("/users/{id}", getUser, gofr.Cache(gofr.CacheConfig{
+//		Control: "public, max-age=60",
+//		TTL:     5 * time.Minute,
+//		Tags:    []string{"user", "user:{id}"},
+//	}))
+func Cache(cfg CacheConfig) RouteOption {
+	return routeOption{
+		handle: func(c *routeConfig) {
+			c.cache = &cfg
+		},
+	}
+}
+
+// InvalidateCache removes the responses stored with any of the tags by the routes caching their responses, e.g. to
+// purge the cached responses of an entity once it is updated:
+//
+//	app.GET("/users/{id}", getUser, gofr.Cache(gofr.CacheConfig{TTL: time.Hour, Tags: []string{"user:{id}"}}))
+//
+//	func updateUser(c *gofr.Context) (interface{}, error) {
+//		...
+//		return user, c.InvalidateCache("user:" + c.PathParam("id"))
+//	}
+func (c *Context) InvalidateCache(tags ...string) error {
+	if c.cache == nil || len(tags) == 0 {
+		return nil
+	}
+
+	return c.cache.invalidate(c, tags...)
+}
+
+// cacheStore creates the store of the cached responses from the HTTP_CACHE_STORE config, which is memory (default)
+// or redis for the responses to be shared by the replicas of the app. The memory store holds up to
+// HTTP_CACHE_MAX_ENTRIES responses, 10000 by default.
+func (a *App) cacheStore() cacheStore {
+	switch store := a.Config.GetOrDefault("HTTP_CACHE_STORE", "memory"); store {
+	case "memory":
+	case "redis":
+		if a.container.Redis != nil {
+			return &redisCacheStore{client: a.container.Redis}
+		}
+
+		a.container.Error("Redis is not configured for HTTP_CACHE_STORE, the responses are cached in memory")
+	default:
+		a.container.Errorf("invalid value %q for HTTP_CACHE_STORE, the responses are cached in memory", store)
+	}
+
+	var maxEntries int
+
+	if value := a.Config.Get("HTTP_CACHE_MAX_ENTRIES"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			a.container.Errorf("invalid value %q for HTTP_CACHE_MAX_ENTRIES, using default of %d", value, defaultCacheMaxEntries)
+		} else {
+			maxEntries = n
+		}
+	}
+
+	return newMemoryCacheStore(maxEntries)
+}
+
+// serveCached serves the GET and HEAD requests to a route caching its responses. The stored response is served when
+// there is one, otherwise the response of the handler is stored when it is successful. The successful responses are
+// given their validators and are responded to with 304 Not Modified when the client already has them.
+func (h handler) serveCached(w http.ResponseWriter, r *http.Request) {
+	store := h.cacheStore
+
+	// the responses to the requests carrying credentials are only shared by the requests with the same credentials.
+	if h.cache.TTL <= 0 || (r.Header.Get("Authorization") != "" && !varies(h.cache.Vary, "Authorization")) {
+		store = nil
+	}
+
+	key := cacheKey(r, h.cache.Vary)
+
+	if store != nil {
+		res, err := store.get(r.Context(), key)
+		if err != nil {
+			h.container.Errorf("could not read the cached response of %v: %v", r.URL.Path, err)
+		}
+
+		if res != nil {
+			writeCachedResponse(w, r, res)
+
+			return
+		}
+	}
+
+	// the responses to HEAD requests are the ones to GET requests without their body, so that they share the ETag.
+	req := r
+	if r.Method == http.MethodHead {
+		req = r.Clone(r.Context())
+		req.Method = http.MethodGet
+	}
+
+	rec := &cacheRecorder{w: w, header: make(http.Header)}
+
+	h.serve(rec, req)
+
+	if rec.streamed {
+		return
+	}
+
+	res := &cachedResponse{StatusCode: rec.statusCode(), Header: rec.header, Body: rec.body.Bytes()}
+
+	if res.StatusCode == http.StatusOK {
+		if !storable(res.Header, h.cache.Control) {
+			store = nil
+		}
+
+		h.setValidators(res, store != nil)
+
+		if store != nil {
+			tags := cacheTags(h.cache.Tags, mux.Vars(r))
+
+			if err := store.set(r.Context(), key, res, tags, h.cache.TTL); err != nil {
+				h.container.Errorf("could not cache the response of %v: %v", r.URL.Path, err)
+			}
+		}
+	}
+
+	writeCachedResponse(w, r, res)
+}
+
+// storable reports whether a response with the given headers can be stored, which is not the case of the responses
+// setting cookies or whose Cache-Control header forbids shared caches to store them. The Cache-Control of the route,
+// control, applies when the handler does not set one.
+func storable(header http.Header, control string) bool {
+	if len(header.Values("Set-Cookie")) > 0 {
+		return false
+	}
+
+	if header.Get("Cache-Control") != "" {
+		control = header.Get("Cache-Control")
+	}
+
+	for _, directive := range strings.Split(control, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+		if strings.EqualFold(name, "private") || strings.EqualFold(name, "no-store") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// varies reports whether the header is one of the vary headers.
+func varies(vary []string, header string) bool {
+	return slices.ContainsFunc(vary, func(name string) bool { return strings.EqualFold(name, header) })
+}
+
+// setValidators sets the headers of the successful responses used by the clients and the caches to validate them,
+// unless the handler sets them. The responses which are stored are also given the time at which they are stored.
+func (h handler) setValidators(res *cachedResponse, stored bool) {
+	if res.Header.Get("ETag") == "" {
+		sum := sha256.Sum256(res.Body)
+		res.Header.Set("ETag", `"`+base64.RawURLEncoding.EncodeToString(sum[:])+`"`)
+	}
+
+	if stored && res.Header.Get("Last-Modified") == "" {
+		res.Header.Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	}
+
+	if h.cache.Control != "" && res.Header.Get("Cache-Control") == "" {
+		res.Header.Set("Cache-Control", h.cache.Control)
+	}
+
+	// the responses are encoded in the media type accepted by the client.
+	res.Header.Add("Vary", strings.Join(append([]string{"Accept"}, h.cache.Vary...), ", "))
+}
+
+// writeCachedResponse writes res, or 304 Not Modified when it is successful and matches the conditional headers of r.
+func writeCachedResponse(w http.ResponseWriter, r *http.Request, res *cachedResponse) {
+	for name, values := range res.Header {
+		// the Vary headers of the outer middlewares, like the ones of CORS and compression, are kept.
+		if name == "Vary" {
+			w.Header()[name] = append(w.Header()[name], values...)
+		} else {
+			w.Header()[name] = slices.Clone(values)
+		}
+	}
+
+	if res.StatusCode == http.StatusOK && notModified(r, res.Header) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.WriteHeader(res.StatusCode)
+
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(res.Body)
+	}
+}
+
+// notModified reports whether the client already has the response with the given headers, following its
+// If-None-Match header, or its If-Modified-Since header when it does not send the former.
+func notModified(r *http.Request, header http.Header) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		etag := strings.TrimPrefix(header.Get("ETag"), "W/")
+
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimSpace(tag)
+
+			if tag == "*" || (etag != "" && strings.TrimPrefix(tag, "W/") == etag) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+
+	return err == nil && !modified.After(since)
+}
+
+// cacheKey returns the key of the response to r, which depends on its path and query parameters, on the media type it
+// accepts and on the values of the vary headers.
+func cacheKey(r *http.Request, vary []string) string {
+	hash := sha256.New()
+
+	_, _ = io.WriteString(hash, r.URL.Path+"?"+r.URL.Query().Encode())
+
+	for _, name := range append([]string{"Accept"}, vary...) {
+		_, _ = io.WriteString(hash, "\n"+http.CanonicalHeaderKey(name)+": "+strings.Join(r.Header.Values(name), ", "))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// cacheTags returns the tags of a route with their path parameters, like user:{id}, replaced by their values.
+func cacheTags(tags []string, vars map[string]string) []string {
+	expanded := make([]string, len(tags))
+
+	for i, tag := range tags {
+		for name, value := range vars {
+			tag = strings.ReplaceAll(tag, "{"+name+"}", value)
+		}
+
+		expanded[i] = tag
+	}
+
+	return expanded
+}
+
+// cacheRecorder records the response of the handler of a route caching its responses. The streamed responses are
+// written to the client as they are flushed, without being recorded.
+type cacheRecorder struct {
+	w        http.ResponseWriter
+	header   http.Header
+	status   int
+	body     bytes.Buffer
+	streamed bool
+}
+
+func (rec *cacheRecorder) Header() http.Header {
+	if rec.streamed {
+		return rec.w.Header()
+	}
+
+	return rec.header
+}
+
+func (rec *cacheRecorder) WriteHeader(status int) {
+	if rec.streamed {
+		rec.w.WriteHeader(status)
+
+		return
+	}
+
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *cacheRecorder) Write(b []byte) (int, error) {
+	if rec.streamed {
+		return rec.w.Write(b)
+	}
+
+	return rec.body.Write(b)
+}
+
+func (rec *cacheRecorder) statusCode() int {
+	if rec.status == 0 {
+		return http.StatusOK
+	}
+
+	return rec.status
+}
+
+// Flush writes the response recorded so far to the client, the rest of the response being written as it is.
+func (rec *cacheRecorder) Flush() {
+	if !rec.streamed {
+		rec.streamed = true
+
+		for name, values := range rec.header {
+			rec.w.Header()[name] = values
+		}
+
+		rec.w.WriteHeader(rec.statusCode())
+		_, _ = rec.w.Write(rec.body.Bytes())
+	}
+
+	if f, ok := rec.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,259 @@
+package gofr
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// cacheSweepInterval is the interval at which the memory store removes the expired responses.
+	cacheSweepInterval = time.Minute
+
+	// defaultCacheMaxEntries is the number of responses held by the memory store by default.
+	defaultCacheMaxEntries = 10000
+)
+
+// cachedResponse is a response stored by a route caching its responses.
+type cachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cacheStore holds the responses of the routes caching them, in memory for a single instance or in Redis for the
+// responses to be shared by the replicas of an app.
+type cacheStore interface {
+	// get returns the response stored for key, nil when there is none.
+	get(ctx context.Context, key string) (*cachedResponse, error)
+
+	// set stores the response for key with the given tags, until it expires after ttl.
+	set(ctx context.Context, key string, res *cachedResponse, tags []string, ttl time.Duration) error
+
+	// invalidate removes the responses stored with any of the tags.
+	invalidate(ctx context.Context, tags ...string) error
+}
+
+type cacheEntry struct {
+	key     string
+	res     *cachedResponse
+	tags    []string
+	expires time.Time
+}
+
+// memoryCacheStore holds up to maxEntries responses, evicting the least recently used ones first, as the keys of the
+// responses depend on the query parameters and headers of the requests, which are chosen by the clients.
+type memoryCacheStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+	tags       map[string]map[string]struct{}
+	lastSweep  time.Time
+	now        func() time.Time
+}
+
+func newMemoryCacheStore(maxEntries int) *memoryCacheStore {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheMaxEntries
+	}
+
+	return &memoryCacheStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		tags:       make(map[string]map[string]struct{}),
+		now:        time.Now,
+	}
+}
+
+func (s *memoryCacheStore) get(_ context.Context, key string) (*cachedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	e := elem.Value.(*cacheEntry)
+	if !s.now().Before(e.expires) {
+		return nil, nil
+	}
+
+	s.lru.MoveToFront(elem)
+
+	return e.res, nil
+}
+
+func (s *memoryCacheStore) set(_ context.Context, key string, res *cachedResponse, tags []string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	s.remove(key)
+	s.entries[key] = s.lru.PushFront(&cacheEntry{key: key, res: res, tags: tags, expires: now.Add(ttl)})
+
+	for s.lru.Len() > s.maxEntries {
+		s.remove(s.lru.Back().Value.(*cacheEntry).key)
+	}
+
+	for _, tag := range tags {
+		if s.tags[tag] == nil {
+			s.tags[tag] = make(map[string]struct{})
+		}
+
+		s.tags[tag][key] = struct{}{}
+	}
+
+	return nil
+}
+
+func (s *memoryCacheStore) invalidate(_ context.Context, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.remove(key)
+		}
+	}
+
+	return nil
+}
+
+// remove removes the response of key along with its references from the tags.
+func (s *memoryCacheStore) remove(key string) {
+	elem, ok := s.entries[key]
+	if !ok {
+		return
+	}
+
+	e := s.lru.Remove(elem).(*cacheEntry)
+	delete(s.entries, key)
+
+	for _, tag := range e.tags {
+		delete(s.tags[tag], key)
+
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+}
+
+func (s *memoryCacheStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < cacheSweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, elem := range s.entries {
+		if !now.Before(elem.Value.(*cacheEntry).expires) {
+			s.remove(key)
+		}
+	}
+}
+
+const cacheKeyPrefix = "gofr:cache:"
+
+// tagCacheScript adds ARGV[1] to the set of the tag in KEYS[1], which is kept for at least ARGV[2] milliseconds, as
+// long as the response it refers to. The scripts only use the key of a single tag, as the keys of the responses and of
+// their tags are in different hash slots of Redis Cluster.
+var tagCacheScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+redis.call('SADD', KEYS[1], ARGV[1])
+if redis.call('PTTL', KEYS[1]) < ttl then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+
+return 1
+`)
+
+// popCacheTagScript removes the set of the tag in KEYS[1], returning the keys of the responses it refers to.
+var popCacheTagScript = redis.NewScript(`
+local keys = redis.call('SMEMBERS', KEYS[1])
+redis.call('DEL', KEYS[1])
+
+return keys
+`)
+
+type redisCacheStore struct {
+	client redis.Cmdable
+}
+
+func (s *redisCacheStore) get(ctx context.Context, key string) (*cachedResponse, error) {
+	value, err := s.client.Get(ctx, cacheKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var res cachedResponse
+
+	if err := json.Unmarshal(value, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (s *redisCacheStore) set(ctx context.Context, key string, res *cachedResponse, tags []string, ttl time.Duration) error {
+	value, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Set(ctx, cacheKeyPrefix+key, value, ttl).Err(); err != nil {
+		return err
+	}
+
+	for _, tagKey := range tagKeys(tags) {
+		err := tagCacheScript.Run(ctx, s.client, []string{tagKey}, cacheKeyPrefix+key, ttl.Milliseconds()).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidate removes the sets of the tags, and then the responses they refer to one at a time, for the commands to
+// only use a single key on Redis Cluster.
+func (s *redisCacheStore) invalidate(ctx context.Context, tags ...string) error {
+	for _, tagKey := range tagKeys(tags) {
+		keys, err := popCacheTagScript.Run(ctx, s.client, []string{tagKey}).StringSlice()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := s.client.Del(ctx, key).Err(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func tagKeys(tags []string) []string {
+	keys := make([]string, len(tags))
+
+	for i, tag := range tags {
+		keys[i] = cacheKeyPrefix + "tag:" + tag
+	}
+
+	return keys
+}
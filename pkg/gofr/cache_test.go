@@ -0,0 +1,357 @@
+package gofr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/http/response"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func serveCachedRoute(a *App, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, http.NoBody)
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestApp_Cache(t *testing.T) {
+	a := newTestApp()
+	a.httpServer.cacheStore = newMemoryCacheStore(0)
+
+	calls := 0
+
+	a.GET("/users/{id}", func(c *Context) (interface{}, error) {
+		calls++
+
+		if c.PathParam("id") == "0" {
+			return nil, http.ErrMissingFile
+		}
+
+		return map[string]string{"id": c.PathParam("id")}, nil
+	}, Cache(CacheConfig{Control: "public, max-age=60", TTL: time.Hour, Tags: []string{"user:{id}"}}))
+
+	a.PUT("/users/{id}", func(c *Context) (interface{}, error) {
+		return nil, c.InvalidateCache("user:" + c.PathParam("id"))
+	})
+
+	w := serveCachedRoute(a, http.MethodGet, "/users/1", nil)
+	etag, lastModified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":{"id":"1"}}`+"\n", w.Body.String())
+	assert.NotEmpty(t, etag)
+	assert.NotEmpty(t, lastModified)
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	tests := []struct {
+		desc       string
+		method     string
+		target     string
+		header     http.Header
+		statusCode int
+		body       string
+		calls      int
+	}{
+		{"stored response", http.MethodGet, "/users/1", nil, http.StatusOK, `{"data":{"id":"1"}}` + "\n", 1},
+		{"matching ETag", http.MethodGet, "/users/1", http.Header{"If-None-Match": {`"other", ` + etag}},
+			http.StatusNotModified, "", 1},
+		{"other ETag", http.MethodGet, "/users/1", http.Header{"If-None-Match": {`"other"`}},
+			http.StatusOK, `{"data":{"id":"1"}}` + "\n", 1},
+		{"not modified since", http.MethodGet, "/users/1", http.Header{"If-Modified-Since": {lastModified}},
+			http.StatusNotModified, "", 1},
+		{"HEAD request", http.MethodHead, "/users/1", nil, http.StatusOK, "", 1},
+		{"other user", http.MethodGet, "/users/2", nil, http.StatusOK, `{"data":{"id":"2"}}` + "\n", 2},
+		{"other query", http.MethodGet, "/users/2?fields=id", nil, http.StatusOK, `{"data":{"id":"2"}}` + "\n", 3},
+		{"error not stored", http.MethodGet, "/users/0", nil, http.StatusNotFound, "", 4},
+		{"error not stored again", http.MethodGet, "/users/0", nil, http.StatusNotFound, "", 5},
+		{"invalidation", http.MethodPut, "/users/1", nil, http.StatusOK, "", 5},
+		{"invalidated response", http.MethodGet, "/users/1", nil, http.StatusOK, `{"data":{"id":"1"}}` + "\n", 6},
+		{"other user still stored", http.MethodGet, "/users/2", nil, http.StatusOK, `{"data":{"id":"2"}}` + "\n", 6},
+	}
+
+	for i, tc := range tests {
+		w := serveCachedRoute(a, tc.method, tc.target, tc.header)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.calls, calls, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.body != "" || tc.statusCode == http.StatusNotModified || tc.method == http.MethodHead {
+			assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+
+		if tc.target == "/users/1" && tc.method != http.MethodPut {
+			assert.Equal(t, etag, w.Header().Get("ETag"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestApp_CacheValidators(t *testing.T) {
+	a := newTestApp()
+	a.httpServer.cacheStore = newMemoryCacheStore(0)
+
+	calls := 0
+
+	a.GET("/hello", func(c *Context) (interface{}, error) {
+		calls++
+
+		return "Hello " + c.Param("name"), nil
+	}, Cache(CacheConfig{Vary: []string{"Accept-Language"}}))
+
+	w := serveCachedRoute(a, http.MethodGet, "/hello?name=gofr", nil)
+	etag := w.Header().Get("ETag")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Accept, Accept-Language", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+
+	// the responses are not stored without a TTL, while they are still validated.
+	w = serveCachedRoute(a, http.MethodGet, "/hello?name=gofr", http.Header{"If-None-Match": {"W/" + etag}})
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, 2, calls)
+
+	w = serveCachedRoute(a, http.MethodGet, "/hello?name=world", http.Header{"If-None-Match": {etag}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
+func TestApp_CacheNotStored(t *testing.T) {
+	a := newTestApp()
+	a.httpServer.cacheStore = newMemoryCacheStore(0)
+
+	calls := 0
+
+	handler := func(c *Context) (interface{}, error) {
+		calls++
+
+		switch c.PathParam("name") {
+		case "cookie":
+			return response.Response{Body: "hello", Cookies: []*http.Cookie{{Name: "session", Value: "secret"}}}, nil
+		case "no-store":
+			return response.Response{Body: "hello", Headers: map[string]string{"Cache-Control": "no-store"}}, nil
+		}
+
+		return "hello", nil
+	}
+
+	a.GET("/public/{name}", handler, Cache(CacheConfig{TTL: time.Hour}))
+	a.GET("/private/{name}", handler, Cache(CacheConfig{Control: "Private, max-age=60", TTL: time.Hour}))
+	a.GET("/users/{name}", handler, Cache(CacheConfig{TTL: time.Hour, Vary: []string{"authorization"}}))
+
+	authorization := http.Header{"Authorization": {"Bearer token"}}
+
+	tests := []struct {
+		desc   string
+		target string
+		header http.Header
+		stored bool
+	}{
+		{"response setting a cookie", "/public/cookie", nil, false},
+		{"response forbidding storage", "/public/no-store", nil, false},
+		{"private response of route", "/private/greeting", nil, false},
+		{"request with credentials", "/public/greeting", authorization, false},
+		{"request with credentials of varying route", "/users/greeting", authorization, true},
+		{"response without credentials", "/public/greeting", nil, true},
+	}
+
+	for i, tc := range tests {
+		calls = 0
+
+		serveCachedRoute(a, http.MethodGet, tc.target, tc.header)
+		w := serveCachedRoute(a, http.MethodGet, tc.target, tc.header)
+
+		assert.Equal(t, http.StatusOK, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.stored, calls == 1, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCacheRecorder_Flush(t *testing.T) {
+	w := httptest.NewRecorder()
+	rec := &cacheRecorder{w: w, header: make(http.Header)}
+
+	rec.Header().Set("Content-Type", "text/event-stream")
+	_, _ = rec.Write([]byte("data: 1\n\n"))
+
+	assert.Empty(t, w.Body.String())
+
+	rec.Flush()
+	_, _ = rec.Write([]byte("data: 2\n\n"))
+
+	assert.True(t, rec.streamed)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "data: 1\n\ndata: 2\n\n", w.Body.String())
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := newMemoryCacheStore(0)
+	store.now = func() time.Time { return now }
+
+	res := &cachedResponse{StatusCode: http.StatusOK, Body: []byte("hello")}
+
+	_ = store.set(ctx, "user-1", res, []string{"user", "user:1"}, time.Minute)
+	_ = store.set(ctx, "user-2", res, []string{"user", "user:2"}, time.Hour)
+	_ = store.set(ctx, "orders", res, []string{"order"}, time.Hour)
+
+	got, err := store.get(ctx, "user-1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, res, got)
+
+	_ = store.invalidate(ctx, "user:2")
+
+	got, _ = store.get(ctx, "user-2")
+
+	assert.Nil(t, got)
+
+	// the responses expire after their TTL, and are removed by the next sweep.
+	now = now.Add(time.Minute)
+
+	got, _ = store.get(ctx, "user-1")
+
+	assert.Nil(t, got)
+
+	_ = store.set(ctx, "other", res, nil, time.Hour)
+
+	assert.Len(t, store.entries, 2)
+	assert.Equal(t, map[string]map[string]struct{}{"order": {"orders": {}}}, store.tags)
+
+	_ = store.invalidate(ctx, "order", "unknown")
+
+	assert.Len(t, store.entries, 1)
+}
+
+func TestMemoryCacheStore_MaxEntries(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryCacheStore(2)
+	res := &cachedResponse{StatusCode: http.StatusOK, Body: []byte("hello")}
+
+	_ = store.set(ctx, "/users?page=1", res, []string{"user"}, time.Hour)
+	_ = store.set(ctx, "/users?page=2", res, []string{"user"}, time.Hour)
+
+	// the first response is used, making the second one the least recently used.
+	got, _ := store.get(ctx, "/users?page=1")
+	assert.Equal(t, res, got)
+
+	_ = store.set(ctx, "/users?page=3", res, []string{"user"}, time.Hour)
+
+	got, _ = store.get(ctx, "/users?page=2")
+
+	assert.Nil(t, got)
+	assert.Len(t, store.entries, 2)
+	assert.Equal(t, 2, store.lru.Len())
+	assert.Equal(t, map[string]map[string]struct{}{"user": {"/users?page=1": {}, "/users?page=3": {}}}, store.tags)
+}
+
+func TestRedisCacheStore(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("could not start miniredis: %v", err)
+	}
+
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	store := &redisCacheStore{client: client}
+	res := &cachedResponse{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"abc"`}}, Body: []byte("hello")}
+
+	assert.Nil(t, store.set(ctx, "user-1", res, []string{"user", "user:1"}, time.Minute))
+	assert.Nil(t, store.set(ctx, "user-2", res, []string{"user"}, time.Hour))
+
+	got, err := store.get(ctx, "user-1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, res, got)
+	assert.Equal(t, time.Minute, s.TTL(cacheKeyPrefix+"user-1"))
+	assert.Equal(t, time.Hour, s.TTL(cacheKeyPrefix+"tag:user"))
+
+	assert.Nil(t, store.invalidate(ctx, "user:1"))
+
+	got, err = store.get(ctx, "user-1")
+
+	assert.Nil(t, err)
+	assert.Nil(t, got)
+	assert.True(t, s.Exists(cacheKeyPrefix+"user-2"))
+
+	assert.Nil(t, store.invalidate(ctx, "user"))
+	assert.False(t, s.Exists(cacheKeyPrefix+"user-2"))
+	assert.False(t, s.Exists(cacheKeyPrefix+"tag:user"))
+
+	s.Close()
+
+	_, err = store.get(ctx, "user-1")
+
+	assert.NotNil(t, err)
+}
+
+func TestApp_cacheStore(t *testing.T) {
+	tests := []struct {
+		desc    string
+		configs map[string]string
+		redis   bool
+		store   cacheStore
+	}{
+		{"default", nil, true, &memoryCacheStore{}},
+		{"redis", map[string]string{"HTTP_CACHE_STORE": "redis"}, true, &redisCacheStore{}},
+		{"redis not configured", map[string]string{"HTTP_CACHE_STORE": "redis"}, false, &memoryCacheStore{}},
+		{"invalid store", map[string]string{"HTTP_CACHE_STORE": "disk"}, true, &memoryCacheStore{}},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(tc.configs)
+
+		if tc.redis {
+			a.container, _ = container.NewMockContainer(t)
+		}
+
+		assert.IsType(t, tc.store, a.cacheStore(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_cacheStoreMaxEntries(t *testing.T) {
+	tests := []struct {
+		desc       string
+		value      string
+		maxEntries int
+	}{
+		{"default", "", defaultCacheMaxEntries},
+		{"configured", "500", 500},
+		{"invalid value", "many", defaultCacheMaxEntries},
+		{"negative value", "-1", defaultCacheMaxEntries},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(map[string]string{"HTTP_CACHE_MAX_ENTRIES": tc.value})
+
+		store, ok := a.cacheStore().(*memoryCacheStore)
+
+		assert.True(t, ok, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.maxEntries, store.maxEntries, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
 )
 
 // Request is an abstraction over the actual command with flags. This abstraction is useful because it allows us
@@ -76,6 +79,47 @@ func (r *Request) PathParam(key string) string {
 	return r.params[key]
 }
 
+// Params returns the value of the parameter for key as the only value of the list, as the parameters of a command
+// can not be repeated. It returns nil when the parameter is not set.
+func (r *Request) Params(key string) []string {
+	v, ok := r.params[key]
+	if !ok {
+		return nil
+	}
+
+	return []string{v}
+}
+
+// ParamInt returns the value of the parameter for key as an int, like -count=5.
+func (r *Request) ParamInt(key string) (int, error) {
+	return gofrHTTP.ParamInt(key, r.params[key])
+}
+
+// ParamFloat returns the value of the parameter for key as a float64.
+func (r *Request) ParamFloat(key string) (float64, error) {
+	return gofrHTTP.ParamFloat(key, r.params[key])
+}
+
+// ParamBool returns the value of the parameter for key as a bool, which is true for flags like -verbose.
+func (r *Request) ParamBool(key string) (bool, error) {
+	return gofrHTTP.ParamBool(key, r.params[key])
+}
+
+// ParamTime returns the value of the parameter for key as a time in the RFC 3339 format.
+func (r *Request) ParamTime(key string) (time.Time, error) {
+	return gofrHTTP.ParamTime(key, r.params[key])
+}
+
+// Header returns an empty string, as commands do not have headers.
+func (*Request) Header(string) string {
+	return ""
+}
+
+// Cookie returns an empty string, as commands do not have cookies.
+func (*Request) Cookie(string) string {
+	return ""
+}
+
 func (r *Request) Context() context.Context {
 	return context.Background()
 }
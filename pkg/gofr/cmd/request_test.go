@@ -4,8 +4,11 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
 )
 
 func TestRequest_Bind(t *testing.T) {
@@ -69,3 +72,34 @@ func TestHostName(t *testing.T) {
 
 	assert.Equal(t, hostname, result, "TestHostName Failed!")
 }
+
+func TestRequest_TypedParams(t *testing.T) {
+	r := NewRequest([]string{"command", "-count=5", "--verbose", "-ratio=0.5", "-since=2023-01-02T15:04:05Z", "-name=gofr"})
+
+	count, err := r.ParamInt("count")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, count)
+
+	verbose, err := r.ParamBool("verbose")
+	assert.Nil(t, err)
+	assert.True(t, verbose)
+
+	ratio, err := r.ParamFloat("ratio")
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, ratio)
+
+	since, err := r.ParamTime("since")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), since)
+
+	_, err = r.ParamInt("name")
+	assert.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"name"}}, err)
+
+	_, err = r.ParamInt("limit")
+	assert.Equal(t, gofrHTTP.ErrorMissingParam{Params: []string{"limit"}}, err)
+
+	assert.Equal(t, []string{"gofr"}, r.Params("name"))
+	assert.Nil(t, r.Params("limit"))
+	assert.Equal(t, "", r.Header("name"))
+	assert.Equal(t, "", r.Cookie("name"))
+}
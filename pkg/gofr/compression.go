@@ -0,0 +1,63 @@
+package gofr
+
+import (
+	"net/http"
+	"strconv"
+
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+// compression creates the response compression middleware from the configs, returning nil when it is not enabled:
+//
+//	HTTP_COMPRESSION            true to compress the responses
+//	HTTP_COMPRESSION_MIN_SIZE   size in bytes of the smallest responses which are compressed, 1024 by default
+//	HTTP_COMPRESSION_ENCODINGS  encodings by order of preference, among br, gzip and deflate, all by default
+//	HTTP_DECOMPRESSION_MAX_SIZE size in bytes of the largest decompressed request bodies, 10485760 (10 MB) by default
+func (a *App) compression() func(http.Handler) http.Handler {
+	value := a.Config.Get("HTTP_COMPRESSION")
+	if value == "" {
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		a.container.Errorf("invalid value %q for HTTP_COMPRESSION, the responses are not compressed", value)
+
+		return nil
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	var cfg middleware.CompressionConfig
+
+	if value := a.Config.Get("HTTP_COMPRESSION_MIN_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			a.container.Errorf("invalid value %q for HTTP_COMPRESSION_MIN_SIZE, using default of 1024", value)
+		} else {
+			cfg.MinSize = size
+		}
+	}
+
+	if value := a.Config.Get("HTTP_DECOMPRESSION_MAX_SIZE"); value != "" {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || size <= 0 {
+			a.container.Errorf("invalid value %q for HTTP_DECOMPRESSION_MAX_SIZE, using default of 10485760", value)
+		} else {
+			cfg.MaxDecompressedSize = size
+		}
+	}
+
+	for _, encoding := range splitConfig(a.Config.Get("HTTP_COMPRESSION_ENCODINGS")) {
+		switch encoding {
+		case "br", "gzip", "deflate":
+			cfg.Encodings = append(cfg.Encodings, encoding)
+		default:
+			a.container.Errorf("invalid encoding %q in HTTP_COMPRESSION_ENCODINGS, it is ignored", encoding)
+		}
+	}
+
+	return middleware.Compression(cfg)
+}
@@ -0,0 +1,98 @@
+package gofr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestApp_compression(t *testing.T) {
+	tests := []struct {
+		desc    string
+		configs map[string]string
+		enabled bool
+	}{
+		{"not configured", nil, false},
+		{"enabled", map[string]string{"HTTP_COMPRESSION": "true"}, true},
+		{"disabled", map[string]string{"HTTP_COMPRESSION": "false"}, false},
+		{"invalid value", map[string]string{"HTTP_COMPRESSION": "yes please"}, false},
+		{"invalid options", map[string]string{"HTTP_COMPRESSION": "true", "HTTP_COMPRESSION_MIN_SIZE": "-1",
+			"HTTP_COMPRESSION_ENCODINGS": "zstd", "HTTP_DECOMPRESSION_MAX_SIZE": "none"}, true},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(tc.configs)
+
+		assert.Equal(t, tc.enabled, a.compression() != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_Compression(t *testing.T) {
+	a := newTestApp()
+	a.Config = testutil.NewMockConfig(map[string]string{
+		"HTTP_COMPRESSION": "true", "HTTP_COMPRESSION_MIN_SIZE": "100", "HTTP_COMPRESSION_ENCODINGS": "gzip",
+	})
+
+	message := strings.Repeat("hello world ", 20)
+
+	a.GET("/hello", func(*Context) (interface{}, error) {
+		return message, nil
+	})
+
+	a.httpServer.router.UseMiddleware(a.compression())
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", http.NoBody)
+	req.Header.Set("Accept-Encoding", "br, gzip")
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	r, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("could not decompress the body: %v", err)
+	}
+
+	body, _ := io.ReadAll(r)
+
+	assert.Equal(t, `{"data":"`+message+`"}`+"\n", string(body))
+}
+
+func TestApp_CompressionRequestBodyTooLarge(t *testing.T) {
+	a := newTestApp()
+	a.Config = testutil.NewMockConfig(map[string]string{"HTTP_COMPRESSION": "true", "HTTP_DECOMPRESSION_MAX_SIZE": "1024"})
+
+	a.POST("/users", func(c *Context) (interface{}, error) {
+		var user map[string]string
+
+		return user, c.Bind(&user)
+	})
+
+	a.httpServer.router.UseMiddleware(a.compression())
+
+	var body bytes.Buffer
+
+	gw := gzip.NewWriter(&body)
+	_, _ = gw.Write([]byte(`{"name":"` + strings.Repeat("a", 1<<20) + `"}`))
+	_ = gw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/users", &body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	"gofr.dev/pkg/gofr/metrics"
 	"gofr.dev/pkg/gofr/metrics/exporters"
 	"gofr.dev/pkg/gofr/service"
+	"gofr.dev/pkg/gofr/validation"
 
 	_ "github.com/go-sql-driver/mysql" // This is required to be blank import
 )
@@ -35,16 +37,19 @@ type Container struct {
 	Redis Redis
 	SQL   DB
 	Mongo datasource.Mongo
+
+	validator *validation.Validator
 }
 
 func NewContainer(conf config.Config) *Container {
 	if conf == nil {
-		return &Container{}
+		return &Container{validator: validation.New()}
 	}
 
 	c := &Container{
 		appName:    conf.GetOrDefault("APP_NAME", "gofr-app"),
 		appVersion: conf.GetOrDefault("APP_VERSION", "dev"),
+		validator:  validation.New(),
 	}
 
 	c.Create(conf)
@@ -73,7 +78,10 @@ func (c *Container) Create(conf config.Config) {
 	// Register framework metrics
 	c.registerFrameworkMetrics()
 
-	c.Redis = redis.NewClient(conf, c.Logger, c.metricsManager)
+	// the client is nil when Redis is not configured, which is kept out of the interface for the nil checks to hold.
+	if rc := redis.NewClient(conf, c.Logger, c.metricsManager); rc != nil {
+		c.Redis = rc
+	}
 
 	c.SQL = sql.NewSQL(conf, c.Logger, c.metricsManager)
 
@@ -147,6 +155,8 @@ func (c *Container) registerFrameworkMetrics() {
 	httpBuckets := []float64{.001, .003, .005, .01, .02, .03, .05, .1, .2, .3, .5, .75, 1, 2, 3, 5, 10, 30}
 	c.Metrics().NewHistogram("app_http_response", "Response time of http requests in seconds.", httpBuckets...)
 	c.Metrics().NewHistogram("app_http_service_response", "Response time of http service requests in seconds.", httpBuckets...)
+	c.Metrics().NewCounter("app_http_rate_limited_total", "Number of requests rejected by the rate limiter.")
+	c.Metrics().NewCounter("app_http_panic_total", "Number of panics recovered while serving http requests.")
 
 	// redis metrics
 	redisBuckets := []float64{50, 75, 100, 125, 150, 200, 300, 500, 750, 1000, 1250, 1500, 2000, 2500, 3000}
@@ -163,6 +173,15 @@ func (c *Container) registerFrameworkMetrics() {
 	c.Metrics().NewCounter("app_pubsub_publish_success_count", "Number of successful publish operations.")
 	c.Metrics().NewCounter("app_pubsub_subscribe_total_count", "Number of total subscribe operations.")
 	c.Metrics().NewCounter("app_pubsub_subscribe_success_count", "Number of successful subscribe operations.")
+
+	// websocket metrics
+	c.Metrics().NewCounter("app_websocket_connections_total_count", "Number of total WebSocket connections.")
+	c.Metrics().NewUpDownCounter("app_websocket_active_connections", "Number of active WebSocket connections.")
+
+	// cron metrics
+	cronBuckets := []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600}
+	c.Metrics().NewHistogram("app_cron_job_duration", "Time taken by the runs of cron jobs in seconds.", cronBuckets...)
+	c.Metrics().NewCounter("app_cron_job_failure_count", "Number of failed runs of cron jobs.")
 }
 
 func (c *Container) GetAppName() string {
@@ -173,6 +192,11 @@ func (c *Container) GetAppVersion() string {
 	return c.appVersion
 }
 
+// Validator returns the validator of the structs bound to the requests, holding the custom rules of the app.
+func (c *Container) Validator() *validation.Validator {
+	return c.validator
+}
+
 func (c *Container) GetPublisher() pubsub.Publisher {
 	return c.PubSub
 }
@@ -180,3 +204,23 @@ func (c *Container) GetPublisher() pubsub.Publisher {
 func (c *Container) GetSubscriber() pubsub.Subscriber {
 	return c.PubSub
 }
+
+// Close closes the datasources held by the container in the order SQL, Redis and PubSub. A datasource which fails to
+// close does not stop the remaining ones from being closed, all the errors are returned together.
+func (c *Container) Close() error {
+	var err error
+
+	if c.SQL != nil {
+		err = errors.Join(err, c.SQL.Close())
+	}
+
+	if c.Redis != nil {
+		err = errors.Join(err, c.Redis.Close())
+	}
+
+	if c.PubSub != nil {
+		err = errors.Join(err, c.PubSub.Close())
+	}
+
+	return err
+}
@@ -161,6 +161,26 @@ func TestContainer_newContainerWithNilConfig(t *testing.T) {
 	assert.Nil(t, container.Logger, "%s", failureMsg)
 }
 
+func TestContainer_Close(t *testing.T) {
+	errClose := testutil.CustomError{ErrorMessage: "close error"}
+
+	c, mocks := NewMockContainer(t)
+	c.PubSub = &mockPubSub{}
+
+	mocks.SQL.EXPECT().Close().Return(nil)
+	mocks.Redis.EXPECT().Close().Return(errClose)
+
+	err := c.Close()
+
+	assert.ErrorIs(t, err, errClose)
+}
+
+func TestContainer_CloseWithoutDatasources(t *testing.T) {
+	c := NewContainer(nil)
+
+	assert.NoError(t, c.Close())
+}
+
 type mockPubSub struct {
 }
 
@@ -183,3 +203,7 @@ func (m *mockPubSub) Publish(_ context.Context, _ string, _ []byte) error {
 func (m *mockPubSub) Subscribe(_ context.Context, _ string) (*pubsub.Message, error) {
 	return nil, nil
 }
+
+func (m *mockPubSub) Close() error {
+	return nil
+}
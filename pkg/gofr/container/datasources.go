@@ -23,10 +23,12 @@ type DB interface {
 	Begin() (*gofrSQL.Tx, error)
 	Select(ctx context.Context, data interface{}, query string, args ...interface{})
 	HealthCheck() *datasource.Health
+	Close() error
 }
 
 type Redis interface {
 	redis.Cmdable
 	redis.HashCmdable
 	HealthCheck() datasource.Health
+	Close() error
 }
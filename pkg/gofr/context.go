@@ -2,11 +2,13 @@ package gofr
 
 import (
 	"context"
+	"crypto/x509"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
 	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/validation"
 )
 
 type Context struct {
@@ -24,6 +26,14 @@ type Context struct {
 	// normal response writer as we want to keep the context independent of http. Will help us in writing CMD application
 	// or grpc servers etc using the same handler signature.
 	responder Responder
+
+	// cache holds the cached responses of the routes caching them, which are invalidated using InvalidateCache. It is
+	// nil outside HTTP handlers.
+	cache cacheStore
+
+	// streamCtx is the context of the request without the timeout of its route, returned by StreamContext. It is nil
+	// when the Context has no timeout.
+	streamCtx context.Context
 }
 
 /*
@@ -50,8 +60,59 @@ func (c *Context) Trace(name string) trace.Span {
 	return span
 }
 
+// Bind binds the body of the request to i and validates it using the rules declared in the validate tags of its
+// fields. A validation.Error listing the failing fields is returned when the validation fails, which is responded
+// to with the status code 400 by HTTP handlers.
 func (c *Context) Bind(i interface{}) error {
-	return c.Request.Bind(i)
+	if err := c.Request.Bind(i); err != nil {
+		return err
+	}
+
+	var v *validation.Validator
+	if c.Container != nil {
+		v = c.Validator()
+	}
+
+	return v.Validate(i)
+}
+
+// certificateRequest is implemented by the requests which can carry a client certificate, like the HTTP request.
+type certificateRequest interface {
+	ClientCertificate() *x509.Certificate
+}
+
+// ClientCertificate returns the verified certificate of the caller when the request was made over mutual TLS,
+// otherwise nil. The identity of the caller can be read from its Subject to authorize the request.
+func (c *Context) ClientCertificate() *x509.Certificate {
+	r, ok := c.Request.(certificateRequest)
+	if !ok {
+		return nil
+	}
+
+	return r.ClientCertificate()
+}
+
+// StreamContext returns the context for the goroutines producing the streamed responses and the Server-Sent Events
+// returned by the handler, which outlive it. Unlike the Context itself, it is not canceled by the timeout of the
+// route, but only once the client disconnects or the response is written, e.g.
+//
+//	go func() {
+//		defer close(events)
+//
+//		for {
+//			select {
+//			case <-c.StreamContext().Done():
+//				return
+//			case events <- response.Event{Data: "tick"}:
+//			}
+//		}
+//	}()
+func (c *Context) StreamContext() context.Context {
+	if c.streamCtx == nil {
+		return c.Context
+	}
+
+	return c.streamCtx
 }
 
 // func (c *Context) reset(w Responder, r Request) {
@@ -3,8 +3,14 @@ package gofr
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -35,3 +41,91 @@ func Test_newContextSuccess(t *testing.T) {
 	assert.Equal(t, map[string]string{"key": "value"}, body, "TEST Failed \n unable to read body")
 	assert.Nil(t, err, "TEST Failed \n unable to read body")
 }
+
+func TestContext_ClientCertificate(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing-service"}}
+
+	httpRequest := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	httpRequest.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	c := container.NewContainer(testutil.NewMockConfig(nil))
+
+	ctx := newContext(nil, gofrHTTP.NewRequest(httpRequest), c)
+	assert.Equal(t, "billing-service", ctx.ClientCertificate().Subject.CommonName)
+
+	// requests which are not made over HTTP do not carry a client certificate.
+	ctx = newContext(nil, noopRequest{ctx: context.Background()}, c)
+	assert.Nil(t, ctx.ClientCertificate())
+}
+
+func TestContext_BindValidation(t *testing.T) {
+	type order struct {
+		Item     string `json:"item" validate:"required,sku"`
+		Quantity int    `json:"quantity" validate:"min=1,max=10"`
+	}
+
+	a := newTestApp()
+
+	a.AddValidator("sku", func(value interface{}, _ string) bool {
+		s, ok := value.(string)
+
+		return ok && strings.HasPrefix(s, "SKU-")
+	})
+
+	a.POST("/orders", func(c *Context) (interface{}, error) {
+		var o order
+
+		if err := c.Bind(&o); err != nil {
+			return nil, err
+		}
+
+		return o, nil
+	})
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		response   string
+	}{
+		{"valid order", `{"item":"SKU-1","quantity":2}`, http.StatusCreated, `{"data":{"item":"SKU-1","quantity":2}}`},
+		{"invalid order", `{"item":"pen","quantity":20}`, http.StatusBadRequest, `{"error":{
+			"message":"validation failed: item failed the sku validation; quantity must be at most 10",
+			"fields":[{"field":"item","rule":"sku","reason":"failed the sku validation"},
+				{"field":"quantity","rule":"max","reason":"must be at most 10"}]}}`},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.response, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestContext_StreamContext(t *testing.T) {
+	requestCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerCtx, cancelHandler := context.WithTimeout(requestCtx, time.Millisecond)
+	defer cancelHandler()
+
+	ctx := newContext(nil, noopRequest{ctx: handlerCtx}, container.NewContainer(testutil.NewMockConfig(nil)))
+
+	// the context of the handler is the stream context when the route has no timeout.
+	assert.Equal(t, handlerCtx, ctx.StreamContext())
+
+	ctx.streamCtx = requestCtx
+
+	<-ctx.Done()
+
+	assert.Nil(t, ctx.StreamContext().Err())
+
+	cancel()
+
+	assert.ErrorIs(t, ctx.StreamContext().Err(), context.Canceled)
+}
@@ -0,0 +1,77 @@
+package gofr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+// SetCORS sets the CORS policy of the HTTP routes, replacing the one read from the ACCESS_CONTROL_ configs. The
+// methods allowed by the preflight requests are the ones of the routes matching their path, unless cfg sets them.
+func (a *App) SetCORS(cfg middleware.CORSConfig) {
+	if cfg.AllowCredentials && cfg.AllowsAnyOrigin() {
+		a.container.Error("CORS credentials require a list of allowed origins rather than all of them, " +
+			"credentials are not allowed")
+
+		cfg.AllowCredentials = false
+	}
+
+	if cfg.RouteMethods == nil {
+		cfg.RouteMethods = a.httpServer.router.RouteMethods
+	}
+
+	a.ReplaceMiddleware(gofrHTTP.CORSMiddleware, middleware.CORSWithConfig(cfg))
+}
+
+// corsConfig reads the CORS policy from the configs, whose lists are comma separated:
+//
+//	ACCESS_CONTROL_ALLOW_ORIGIN       allowed origins, like https://example.com,https://*.example.com, all by default
+//	ACCESS_CONTROL_ALLOW_METHODS      allowed methods, the ones of the routes by default
+//	ACCESS_CONTROL_ALLOW_HEADERS      allowed request headers, Authorization, Content-Type and X-API-KEY by default
+//	ACCESS_CONTROL_EXPOSE_HEADERS     response headers readable by the allowed origins
+//	ACCESS_CONTROL_ALLOW_CREDENTIALS  true to allow the requests with credentials, which requires a list of origins
+//	ACCESS_CONTROL_MAX_AGE            seconds for which the responses to the preflight requests can be cached
+func (a *App) corsConfig() middleware.CORSConfig {
+	cfg := middleware.CORSConfig{
+		AllowedOrigins: splitConfig(a.Config.Get("ACCESS_CONTROL_ALLOW_ORIGIN")),
+		AllowedMethods: splitConfig(a.Config.Get("ACCESS_CONTROL_ALLOW_METHODS")),
+		AllowedHeaders: splitConfig(a.Config.Get("ACCESS_CONTROL_ALLOW_HEADERS")),
+		ExposedHeaders: splitConfig(a.Config.Get("ACCESS_CONTROL_EXPOSE_HEADERS")),
+	}
+
+	if value := a.Config.Get("ACCESS_CONTROL_ALLOW_CREDENTIALS"); value != "" {
+		credentials, err := strconv.ParseBool(value)
+		if err != nil {
+			a.container.Errorf("invalid value %q for ACCESS_CONTROL_ALLOW_CREDENTIALS, credentials are not allowed", value)
+		}
+
+		cfg.AllowCredentials = credentials
+	}
+
+	if value := a.Config.Get("ACCESS_CONTROL_MAX_AGE"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			a.container.Errorf("invalid value %q for ACCESS_CONTROL_MAX_AGE, the preflight responses are not cached", value)
+		} else {
+			cfg.MaxAge = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return cfg
+}
+
+// splitConfig splits a comma separated config, returning nil when it is empty.
+func splitConfig(value string) []string {
+	var values []string
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
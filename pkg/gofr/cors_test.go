@@ -0,0 +1,115 @@
+package gofr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/http/middleware"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestApp_corsConfig(t *testing.T) {
+	tests := []struct {
+		desc    string
+		configs map[string]string
+		cfg     middleware.CORSConfig
+	}{
+		{"not configured", nil, middleware.CORSConfig{}},
+		{"configured", map[string]string{
+			"ACCESS_CONTROL_ALLOW_ORIGIN":      "https://example.com, https://*.example.com",
+			"ACCESS_CONTROL_ALLOW_METHODS":     "GET,POST",
+			"ACCESS_CONTROL_ALLOW_HEADERS":     "Authorization,X-Tenant-ID",
+			"ACCESS_CONTROL_EXPOSE_HEADERS":    "X-Correlation-ID",
+			"ACCESS_CONTROL_ALLOW_CREDENTIALS": "true",
+			"ACCESS_CONTROL_MAX_AGE":           "600",
+		}, middleware.CORSConfig{
+			AllowedOrigins:   []string{"https://example.com", "https://*.example.com"},
+			AllowedMethods:   []string{"GET", "POST"},
+			AllowedHeaders:   []string{"Authorization", "X-Tenant-ID"},
+			ExposedHeaders:   []string{"X-Correlation-ID"},
+			AllowCredentials: true,
+			MaxAge:           10 * time.Minute,
+		}},
+		{"invalid values", map[string]string{
+			"ACCESS_CONTROL_ALLOW_CREDENTIALS": "yes please",
+			"ACCESS_CONTROL_MAX_AGE":           "10m",
+		}, middleware.CORSConfig{}},
+	}
+
+	for i, tc := range tests {
+		a := &App{
+			Config:    testutil.NewMockConfig(tc.configs),
+			container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		}
+
+		assert.Equal(t, tc.cfg, a.corsConfig(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_SetCORS(t *testing.T) {
+	a := newTestApp()
+
+	a.GET("/users", func(*Context) (interface{}, error) { return nil, nil })
+	a.POST("/users", func(*Context) (interface{}, error) { return nil, nil })
+
+	a.SetCORS(middleware.CORSConfig{AllowedOrigins: []string{"https://example.com"}})
+
+	// the catch-all route added by Run answers the requests which no route accepts.
+	a.httpServer.router.PathPrefix("/").Handler(handler{function: catchAllHandler, container: a.container})
+
+	tests := []struct {
+		desc        string
+		origin      string
+		path        string
+		statusCode  int
+		allowOrigin string
+		methods     string
+	}{
+		{"preflight of the routes", "https://example.com", "/users", http.StatusNoContent, "https://example.com",
+			"GET, HEAD, POST"},
+		{"preflight of other origin", "https://evil.com", "/users", http.StatusForbidden, "", ""},
+		{"preflight of path without routes", "https://example.com", "/orders", http.StatusNotFound,
+			"https://example.com", ""},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodOptions, tc.path, http.NoBody)
+		req.Header.Set("Origin", tc.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+		w := httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.allowOrigin, w.Header().Get("Access-Control-Allow-Origin"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.methods, w.Header().Get("Access-Control-Allow-Methods"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_SetCORSCredentialsWithAnyOrigin(t *testing.T) {
+	var w *httptest.ResponseRecorder
+
+	logs := testutil.StderrOutputForFunc(func() {
+		a := newTestApp()
+		a.container.Logger = logging.NewLogger(logging.ERROR)
+
+		a.GET("/users", func(*Context) (interface{}, error) { return nil, nil })
+		a.SetCORS(middleware.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+
+		req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		req.Header.Set("Origin", "https://evil.com")
+
+		w = httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, req)
+	})
+
+	assert.Contains(t, logs, "CORS credentials require a list of allowed origins")
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
@@ -0,0 +1,332 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"gofr.dev/pkg/gofr/container"
+)
+
+var (
+	errBadScheduleFormat = errors.New("schedule string must have five components like * * * * * or six with seconds")
+	errOutOfRange        = errors.New("value out of range")
+	errBadStep           = errors.New("step must be a positive number")
+)
+
+// CronFunc is the signature of the jobs run by the cron scheduler of the App.
+type CronFunc func(ctx *Context)
+
+// AddCronJob schedules job to be run at the times matching schedule, which uses the standard cron syntax
+// "minute hour day-of-month month day-of-week", optionally preceded by a seconds field, e.g.
+//
+//	app.AddCronJob("*/5 * * * *", "cache-refresh", refreshCache)    // every five minutes
+//	app.AddCronJob("30 0 2 * * MON", "weekly-report", generateReport) // 02:00:30 every Monday
+//
+// Every run gets its own Context and trace span, and its duration is recorded in the app_cron_job_duration
+// metric. Runs that panic are logged and counted in the app_cron_job_failure_count metric.
+func (a *App) AddCronJob(schedule, jobName string, job CronFunc) {
+	if a.cron == nil {
+		a.container.Logger.Errorf("cron jobs are not supported by command line applications, not adding %s", jobName)
+
+		return
+	}
+
+	if err := a.cron.addJob(schedule, jobName, job); err != nil {
+		a.container.Logger.Errorf("error adding cron job %s: %v", jobName, err)
+	}
+}
+
+// crontab runs the cron jobs of the App, checking every second for the jobs which are due.
+type crontab struct {
+	container *container.Container
+
+	mu   sync.Mutex
+	jobs []*cronJob
+
+	// cancel stops the scheduler and wg tracks it along with the running jobs, so that shutdown can wait for them.
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+type cronJob struct {
+	name     string
+	schedule *schedule
+	fn       CronFunc
+}
+
+func newCrontab(c *container.Container) *crontab {
+	return &crontab{
+		container: c,
+		wg:        &sync.WaitGroup{},
+	}
+}
+
+func (c *crontab) addJob(spec, name string, fn CronFunc) error {
+	s, err := parseSchedule(spec)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.jobs = append(c.jobs, &cronJob{name: name, schedule: s, fn: fn})
+	c.mu.Unlock()
+
+	c.container.Logger.Infof("cron job %s scheduled at %q", name, spec)
+
+	return nil
+}
+
+// start runs the scheduler in its own go-routine.
+func (c *crontab) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			// wake up at the start of every second, as the schedules have seconds precision.
+			now := time.Now()
+			timer := time.NewTimer(now.Truncate(time.Second).Add(time.Second).Sub(now))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return
+			case t := <-timer.C:
+				c.runDue(ctx, t.Truncate(time.Second))
+			}
+		}
+	}()
+}
+
+// runDue starts the jobs scheduled at t, each in its own go-routine.
+func (c *crontab) runDue(ctx context.Context, t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, j := range c.jobs {
+		if !j.schedule.matches(t) {
+			continue
+		}
+
+		c.wg.Add(1)
+
+		go func(j *cronJob) {
+			defer c.wg.Done()
+
+			c.run(ctx, j)
+		}(j)
+	}
+}
+
+func (c *crontab) run(ctx context.Context, j *cronJob) {
+	ctx, span := otel.GetTracerProvider().Tracer("gofr-cron").Start(ctx, "cron "+j.name)
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cron.job", j.name))
+
+	start := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			c.container.Logger.Errorf("cron job %s panicked: %v\n%s", j.name, r, debug.Stack())
+			c.container.Metrics().IncrementCounter(ctx, "app_cron_job_failure_count", "job", j.name)
+			span.SetStatus(codes.Error, fmt.Sprint(r))
+		}
+
+		c.container.Metrics().RecordHistogram(ctx, "app_cron_job_duration", time.Since(start).Seconds(), "job", j.name)
+	}()
+
+	j.fn(newContext(nil, noopRequest{ctx: ctx}, c.container))
+}
+
+// shutdown stops scheduling the jobs and waits for the running ones to complete. It returns
+// when they are done or when ctx is done, whichever happens first. The context of the running jobs
+// is cancelled, so that long-running jobs can stop early.
+func (c *crontab) shutdown(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+
+	c.cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// schedule holds the values matched by each field of a cron schedule as bit sets.
+type schedule struct {
+	sec, min, hour, day, month, dayOfWeek uint64
+
+	// restrictedDays is set when both day fields are restricted, in which case matching either of them is enough.
+	restrictedDays bool
+}
+
+type fieldBounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+	secondBounds    = fieldBounds{name: "second", max: 59}
+	minuteBounds    = fieldBounds{name: "minute", max: 59}
+	hourBounds      = fieldBounds{name: "hour", max: 23}
+	dayBounds       = fieldBounds{name: "day of month", min: 1, max: 31}
+	monthBounds     = fieldBounds{name: "month", min: 1, max: 12, names: monthNames}
+	dayOfWeekBounds = fieldBounds{name: "day of week", max: 7, names: dayNames}
+)
+
+func parseSchedule(spec string) (*schedule, error) {
+	fields := strings.Fields(spec)
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, errBadScheduleFormat
+	}
+
+	var (
+		s    schedule
+		err  error
+		bits = []*uint64{&s.sec, &s.min, &s.hour, &s.day, &s.month, &s.dayOfWeek}
+	)
+
+	for i, bounds := range []fieldBounds{secondBounds, minuteBounds, hourBounds, dayBounds, monthBounds, dayOfWeekBounds} {
+		if *bits[i], err = parseField(fields[i], bounds); err != nil {
+			return nil, err
+		}
+	}
+
+	// both 0 and 7 mean Sunday.
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+
+	s.restrictedDays = !strings.HasPrefix(fields[3], "*") && !strings.HasPrefix(fields[5], "*")
+
+	return &s, nil
+}
+
+// parseField parses a comma separated list of values, ranges like 1-5 and steps like */15 or 10-30/5.
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: %s in %s field", errBadStep, stepPart, bounds.name)
+			}
+		}
+
+		start, end, err := parseRange(rangePart, hasStep, bounds)
+		if err != nil {
+			return 0, err
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func parseRange(r string, hasStep bool, bounds fieldBounds) (start, end int, err error) {
+	if r == "*" {
+		return bounds.min, bounds.max, nil
+	}
+
+	low, high, isRange := strings.Cut(r, "-")
+
+	if start, err = parseValue(low, bounds); err != nil {
+		return 0, 0, err
+	}
+
+	switch {
+	case isRange:
+		end, err = parseValue(high, bounds)
+	case hasStep:
+		// a value with a step, like 5/15, runs from the value till the end of the range.
+		end = bounds.max
+	default:
+		end = start
+	}
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if start > end {
+		return 0, 0, fmt.Errorf("%w: %s in %s field", errOutOfRange, r, bounds.name)
+	}
+
+	return start, end, nil
+}
+
+func parseValue(v string, bounds fieldBounds) (int, error) {
+	if n, ok := bounds.names[strings.ToLower(v)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < bounds.min || n > bounds.max {
+		return 0, fmt.Errorf("%w: %s in %s field", errOutOfRange, v, bounds.name)
+	}
+
+	return n, nil
+}
+
+func (s *schedule) matches(t time.Time) bool {
+	if s.sec&(1<<t.Second()) == 0 || s.min&(1<<t.Minute()) == 0 || s.hour&(1<<t.Hour()) == 0 ||
+		s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	dayMatches := s.day&(1<<t.Day()) != 0
+	dayOfWeekMatches := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+
+	if s.restrictedDays {
+		return dayMatches || dayOfWeekMatches
+	}
+
+	return dayMatches && dayOfWeekMatches
+}
@@ -0,0 +1,157 @@
+package gofr
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestParseSchedule_Errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		schedule string
+		err      error
+	}{
+		{"too few fields", "* * * *", errBadScheduleFormat},
+		{"too many fields", "* * * * * * *", errBadScheduleFormat},
+		{"minute out of range", "60 * * * *", errOutOfRange},
+		{"day of month out of range", "* * 0 * *", errOutOfRange},
+		{"invalid month name", "* * * foo *", errOutOfRange},
+		{"reversed range", "* 10-5 * * *", errOutOfRange},
+		{"zero step", "*/0 * * * *", errBadStep},
+		{"invalid step", "*/a * * * *", errBadStep},
+	}
+
+	for i, tc := range testCases {
+		_, err := parseSchedule(tc.schedule)
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	// 2024-03-04 was a Monday.
+	monday := time.Date(2024, time.March, 4, 10, 15, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		schedule string
+		time     time.Time
+		matches  bool
+	}{
+		{"every minute", "* * * * *", monday, true},
+		{"every minute at non zero second", "* * * * *", monday.Add(time.Second), false},
+		{"every second", "* * * * * *", monday.Add(time.Second), true},
+		{"every 5 minutes", "*/5 * * * *", monday, true},
+		{"every 5 minutes not due", "*/5 * * * *", monday.Add(time.Minute), false},
+		{"step from value", "10/5 * * * *", monday, true},
+		{"range with step", "0-10/5 * * * *", monday, false},
+		{"list of hours", "15 9,10 * * *", monday, true},
+		{"seconds field", "30 15 10 * * *", monday.Add(30 * time.Second), true},
+		{"day of week name", "15 10 * * MON", monday, true},
+		{"day of week range", "15 10 * * tue-fri", monday, false},
+		{"sunday as 7", "15 10 * * 7", monday.AddDate(0, 0, 6), true},
+		{"month name", "15 10 * mar *", monday, true},
+		{"either of restricted days", "15 10 1 * mon", monday, true},
+		{"neither of restricted days", "15 10 1 * tue", monday, false},
+		{"day of month with any day of week", "15 10 4 * *", monday, true},
+	}
+
+	for i, tc := range testCases {
+		s, err := parseSchedule(tc.schedule)
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.matches, s.matches(tc.time), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_AddCronJob(t *testing.T) {
+	var runs atomic.Int32
+
+	app := &App{container: container.NewContainer(testutil.NewMockConfig(nil))}
+	app.cron = newCrontab(app.container)
+
+	app.AddCronJob("* * * * * *", "counter", func(c *Context) {
+		assert.NotNil(t, c.Logger, "container is not available in the context of the job")
+
+		runs.Add(1)
+	})
+
+	app.cron.start()
+
+	assert.Eventually(t, func() bool { return runs.Load() > 0 }, 3*time.Second, 50*time.Millisecond)
+
+	assert.Nil(t, app.cron.shutdown(context.Background()))
+}
+
+func TestApp_AddCronJobInvalidSchedule(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		app := &App{container: container.NewContainer(testutil.NewMockConfig(nil))}
+		app.cron = newCrontab(app.container)
+
+		app.AddCronJob("* * *", "invalid", func(*Context) {})
+
+		assert.Empty(t, app.cron.jobs)
+	})
+
+	assert.Contains(t, logs, "error adding cron job invalid")
+}
+
+func TestApp_AddCronJobCMD(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		app := &App{container: container.NewContainer(testutil.NewMockConfig(nil))}
+
+		app.AddCronJob("* * * * *", "cmd-job", func(*Context) {})
+	})
+
+	assert.Contains(t, logs, "cron jobs are not supported by command line applications")
+}
+
+func TestCrontab_RunPanic(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		c := newCrontab(container.NewContainer(testutil.NewMockConfig(nil)))
+
+		c.run(context.Background(), &cronJob{name: "failing", fn: func(*Context) {
+			panic("job failed")
+		}})
+	})
+
+	assert.Contains(t, logs, "cron job failing panicked: job failed")
+}
+
+func TestCrontab_Shutdown(t *testing.T) {
+	c := newCrontab(container.NewContainer(testutil.NewMockConfig(nil)))
+
+	// shutdown without starting the scheduler has nothing to stop.
+	assert.Nil(t, c.shutdown(context.Background()))
+
+	c.start()
+
+	stopped := make(chan struct{})
+
+	// a running job which only returns once its context is cancelled.
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+
+		c.run(context.Background(), &cronJob{name: "slow", fn: func(*Context) {
+			<-stopped
+		}})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, c.shutdown(ctx), context.DeadlineExceeded)
+
+	close(stopped)
+
+	assert.Nil(t, c.shutdown(context.Background()))
+}
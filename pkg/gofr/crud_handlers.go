@@ -1,17 +1,19 @@
 package gofr
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
-)
 
-var (
-	errInvalidObject  = errors.New("unexpected object given for AddRESTHandlers")
-	errEntityNotFound = errors.New("entity not found")
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/openapi"
 )
 
+var errInvalidObject = errors.New("unexpected object given for AddRESTHandlers")
+
 type Create interface {
 	Create(c *Context) (interface{}, error)
 }
@@ -67,36 +69,52 @@ func scanEntity(object interface{}) (*entity, error) {
 	}, nil
 }
 
-// registerCRUDHandlers registers CRUD handlers for an entity.
-func (a *App) registerCRUDHandlers(e entity, object interface{}) {
+// registerCRUDHandlers registers CRUD handlers for an entity, the options applying to the routes getting the entities.
+func (a *App) registerCRUDHandlers(e entity, object interface{}, options ...RouteOption) {
+	var (
+		collection = fmt.Sprintf("/%s", e.name)
+		single     = fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey)
+
+		// the OpenAPI document of the routes is derived from the entity struct.
+		entityValue = reflect.New(e.entityType).Interface()
+		entities    = reflect.New(reflect.SliceOf(e.entityType)).Elem().Interface()
+		tags        = openapi.Tags(e.name)
+
+		// the options of the caller apply after the default ones, while the cache tags apply to their CacheConfig.
+		getAll = append(append([]RouteOption{tags, openapi.Summary("Get all the " + e.name + " entities"),
+			openapi.Response(entities)}, options...), withCacheTags(e.name))
+		get = append(append([]RouteOption{tags, openapi.Summary("Get a " + e.name), openapi.Response(entityValue)},
+			options...), withCacheTags(e.name, e.name+":{"+e.primaryKey+"}"))
+	)
+
 	if fn, ok := object.(Create); ok {
-		a.POST(fmt.Sprintf("/%s", e.name), fn.Create)
+		a.POST(collection, fn.Create, tags, openapi.Summary("Create a "+e.name), openapi.Request(entityValue))
 	} else {
-		a.POST(fmt.Sprintf("/%s", e.name), e.Create)
+		a.POST(collection, e.Create, tags, openapi.Summary("Create a "+e.name), openapi.Request(entityValue))
 	}
 
 	if fn, ok := object.(GetAll); ok {
-		a.GET(fmt.Sprintf("/%s", e.name), fn.GetAll)
+		a.GET(collection, fn.GetAll, getAll...)
 	} else {
-		a.GET(fmt.Sprintf("/%s", e.name), e.GetAll)
+		a.GET(collection, e.GetAll, getAll...)
 	}
 
 	if fn, ok := object.(Get); ok {
-		a.GET(fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey), fn.Get)
+		a.GET(single, fn.Get, get...)
 	} else {
-		a.GET(fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey), e.Get)
+		a.GET(single, e.Get, get...)
 	}
 
 	if fn, ok := object.(Update); ok {
-		a.PUT(fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey), fn.Update)
+		a.PUT(single, fn.Update, tags, openapi.Summary("Update a "+e.name), openapi.Request(entityValue))
 	} else {
-		a.PUT(fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey), e.Update)
+		a.PUT(single, e.Update, tags, openapi.Summary("Update a "+e.name), openapi.Request(entityValue))
 	}
 
 	if fn, ok := object.(Delete); ok {
-		a.DELETE(fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey), fn.Delete)
+		a.DELETE(single, fn.Delete, tags, openapi.Summary("Delete a "+e.name))
 	} else {
-		a.DELETE(fmt.Sprintf("/%s/{%s}", e.name, e.primaryKey), e.Delete)
+		a.DELETE(single, e.Delete, tags, openapi.Summary("Delete a "+e.name))
 	}
 }
 
@@ -128,13 +146,28 @@ func (e *entity) Create(c *Context) (interface{}, error) {
 		return nil, err
 	}
 
+	e.invalidateCache(c)
+
 	return fmt.Sprintf("%s successfully created with id: %d", e.name, fieldValues[0]), nil
 }
 
+// GetAll responds with the page of the entities requested with the pagination query parameters, like ?page=2&limit=20.
 func (e *entity) GetAll(c *Context) (interface{}, error) {
-	query := fmt.Sprintf("SELECT * FROM %s", e.name)
+	p, err := c.Pagination()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
+
+	err = c.SQL.QueryRowContext(c, fmt.Sprintf("SELECT COUNT(*) FROM %s", e.name)).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := c.SQL.QueryContext(c, query)
+	query := fmt.Sprintf("SELECT * FROM %s LIMIT ? OFFSET ?", e.name)
+
+	rows, err := c.SQL.QueryContext(c, query, p.Limit, p.Offset)
 	if err != nil || rows.Err() != nil {
 		return nil, err
 	}
@@ -167,7 +200,7 @@ func (e *entity) GetAll(c *Context) (interface{}, error) {
 		entities = append(entities, newEntity)
 	}
 
-	return entities, nil
+	return p.Response(entities, total), nil
 }
 
 func (e *entity) Get(c *Context) (interface{}, error) {
@@ -184,6 +217,10 @@ func (e *entity) Get(c *Context) (interface{}, error) {
 	}
 
 	err := row.Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +267,8 @@ func (e *entity) Update(c *Context) (interface{}, error) {
 		return nil, err
 	}
 
+	e.invalidateCache(c)
+
 	return fmt.Sprintf("%s successfully updated with id: %s", e.name, id), nil
 }
 
@@ -248,8 +287,39 @@ func (e *entity) Delete(c *Context) (interface{}, error) {
 	}
 
 	if rowsAffected == 0 {
-		return nil, errEntityNotFound
+		return nil, gofrHTTP.ErrorEntityNotFound{Name: e.primaryKey, Value: id}
 	}
 
+	e.invalidateCache(c)
+
 	return fmt.Sprintf("%s successfully deleted with id: %v", e.name, id), nil
 }
+
+// withCacheTags adds the tags to the cached responses of a route getting entities, when it caches them.
+func withCacheTags(tags ...string) RouteOption {
+	return routeOption{
+		handle: func(c *routeConfig) {
+			if c.cache == nil {
+				return
+			}
+
+			cfg := *c.cache
+			cfg.Tags = append(slices.Clip(cfg.Tags), tags...)
+			c.cache = &cfg
+		},
+	}
+}
+
+// invalidateCache purges the cached responses tagged with the name of the entity once it is changed, along with the
+// ones of the entity with the id of the request, if any. The change is responded to even when the cache can not be
+// purged, as it is already made.
+func (e *entity) invalidateCache(c *Context) {
+	tags := []string{e.name}
+	if id := c.PathParam(e.primaryKey); id != "" {
+		tags = append(tags, e.name+":"+id)
+	}
+
+	if err := c.InvalidateCache(tags...); err != nil {
+		c.Errorf("could not invalidate the cached responses of %s: %v", e.name, err)
+	}
+}
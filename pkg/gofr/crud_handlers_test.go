@@ -2,7 +2,7 @@ package gofr
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gorilla/mux"
@@ -19,6 +20,7 @@ import (
 	"gofr.dev/pkg/gofr/container"
 	gofrSql "gofr.dev/pkg/gofr/datasource/sql"
 	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
 )
 
 var (
@@ -32,7 +34,7 @@ func createTestContext(method, path, id string, body []byte, cont *container.Con
 	testReq.Header.Set("Content-Type", "application/json")
 	gofrReq := gofrHTTP.NewRequest(testReq)
 
-	return newContext(gofrHTTP.NewResponder(httptest.NewRecorder(), method), gofrReq, cont)
+	return newContext(gofrHTTP.NewResponder(httptest.NewRecorder(), method).WithRequest(testReq), gofrReq, cont)
 }
 
 func Test_scanEntity(t *testing.T) {
@@ -110,7 +112,7 @@ func Test_CreateHandler(t *testing.T) {
 func Test_GetAllHandler(t *testing.T) {
 	c := container.NewContainer(nil)
 
-	db, mock, _ := gofrSql.NewSQLMocks(t)
+	db, mock, mockMetrics := gofrSql.NewSQLMocks(t)
 	defer db.Close()
 	c.SQL = db
 
@@ -133,16 +135,20 @@ func Test_GetAllHandler(t *testing.T) {
 		expectedErr  error
 	}{
 		{"success case", sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe").AddRow(2, "Jane Doe"),
-			nil, []interface{}{&user{ID: 1, Name: "John Doe"}, &user{ID: 2, Name: "Jane Doe"}}, nil},
+			nil, response.Paginated{Data: []interface{}{&user{ID: 1, Name: "John Doe"}, &user{ID: 2, Name: "Jane Doe"}},
+				Page: 2, Limit: 2, Offset: 2, Total: 5}, nil},
 		{"error retrieving rows", sqlmock.NewRows([]string{"id", "name"}), errTest, nil, errTest},
 		{"error scanning rows", sqlmock.NewRows([]string{"id", "name"}).AddRow("as", ""),
 			nil, nil, errSQLScan},
 	}
 
 	for i, tc := range tests {
-		ctx := createTestContext(http.MethodGet, "/users", "", nil, c)
+		ctx := createTestContext(http.MethodGet, "/users", "?page=2&limit=2", nil, c)
 
-		mock.ExpectQuery("SELECT * FROM user").WillReturnRows(tc.mockResp).WillReturnError(tc.mockErr)
+		mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "type", "SELECT")
+		mock.ExpectQuery("SELECT COUNT(*) FROM user").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectQuery("SELECT * FROM user LIMIT ? OFFSET ?").WithArgs(2, 2).
+			WillReturnRows(tc.mockResp).WillReturnError(tc.mockErr)
 
 		resp, err := e.GetAll(ctx)
 
@@ -156,6 +162,30 @@ func Test_GetAllHandler(t *testing.T) {
 	}
 }
 
+func Test_GetAllHandler_Errors(t *testing.T) {
+	c := container.NewContainer(nil)
+
+	db, mock, mockMetrics := gofrSql.NewSQLMocks(t)
+	defer db.Close()
+	c.SQL = db
+
+	e := entity{name: "user", entityType: reflect.TypeOf(struct{ ID int }{}), primaryKey: "id"}
+
+	// the query parameters are added in place of the id of the path.
+	resp, err := e.GetAll(createTestContext(http.MethodGet, "/users", "?limit=0", nil, c))
+
+	assert.Nil(t, resp)
+	assert.Equal(t, gofrHTTP.ErrorInvalidParam{Params: []string{"limit"}}, err)
+
+	mockMetrics.EXPECT().RecordHistogram(gomock.Any(), "app_sql_stats", gomock.Any(), "type", "SELECT")
+	mock.ExpectQuery("SELECT COUNT(*) FROM user").WillReturnError(errTest)
+
+	resp, err = e.GetAll(createTestContext(http.MethodGet, "/users", "", nil, c))
+
+	assert.Nil(t, resp)
+	assert.Equal(t, errTest, err)
+}
+
 func Test_GetHandler(t *testing.T) {
 	c := container.NewContainer(nil)
 
@@ -183,7 +213,7 @@ func Test_GetHandler(t *testing.T) {
 	}{
 		{"success case", "1", sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"),
 			&user{ID: 1, Name: "John Doe"}, nil},
-		{"no rows found", "2", sqlmock.NewRows(nil), nil, sql.ErrNoRows},
+		{"no rows found", "2", sqlmock.NewRows(nil), nil, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "2"}},
 		{"error scanning rows", "3", sqlmock.NewRows([]string{"id", "name"}).AddRow("as", ""),
 			nil, errSQLScan},
 	}
@@ -278,7 +308,7 @@ func Test_DeleteHandler(t *testing.T) {
 			nil, "user successfully deleted with id: 1"},
 		{"SQL error case", "2", nil, errTest, errTest, nil},
 		{"no rows affected", "3", sqlmock.NewResult(0, 0), nil,
-			errEntityNotFound, nil},
+			gofrHTTP.ErrorEntityNotFound{Name: "id", Value: "3"}, nil},
 	}
 
 	for i, tc := range tests {
@@ -293,3 +323,56 @@ func Test_DeleteHandler(t *testing.T) {
 		assert.Equal(t, tc.expectedErr, err, "TEST[%d], Failed.\n%s", i, tc.desc)
 	}
 }
+
+type cachedUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (*cachedUser) GetAll(*Context) (interface{}, error) {
+	return []cachedUser{{ID: 1, Name: "gofr"}}, nil
+}
+
+func (*cachedUser) Get(c *Context) (interface{}, error) {
+	return cachedUser{ID: 1, Name: c.PathParam("id")}, nil
+}
+
+func TestApp_AddRESTHandlersCache(t *testing.T) {
+	a := newTestApp()
+	store := newMemoryCacheStore(0)
+	a.httpServer.cacheStore = store
+
+	err := a.AddRESTHandlers(&cachedUser{}, Cache(CacheConfig{TTL: time.Minute, Tags: []string{"users"}}))
+
+	assert.Nil(t, err)
+
+	for _, path := range []string{"/cachedUser", "/cachedUser/1"} {
+		a.httpServer.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	}
+
+	tags := make(map[string]int)
+
+	for tag, keys := range store.tags {
+		tags[tag] = len(keys)
+	}
+
+	// only the routes getting the entities are cached, tagged with the entity and the id of the entity they hold.
+	assert.Equal(t, map[string]int{"users": 2, "cachedUser": 2, "cachedUser:1": 1}, tags)
+}
+
+func TestEntity_invalidateCache(t *testing.T) {
+	store := newMemoryCacheStore(0)
+	res := &cachedResponse{StatusCode: http.StatusOK}
+
+	_ = store.set(context.Background(), "user-1", res, []string{"user:1"}, time.Minute)
+	_ = store.set(context.Background(), "user-2", res, []string{"user:2"}, time.Minute)
+
+	ctx := createTestContext(http.MethodPut, "/user", "1", nil, container.NewContainer(nil))
+	ctx.cache = store
+
+	e := entity{name: "user", primaryKey: "id"}
+	e.invalidateCache(ctx)
+
+	assert.Len(t, store.entries, 1)
+	assert.NotNil(t, store.entries["user-2"])
+}
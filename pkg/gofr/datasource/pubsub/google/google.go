@@ -198,3 +198,12 @@ func (g *googleClient) CreateTopic(ctx context.Context, name string) error {
 
 	return err
 }
+
+// Close closes the google pubsub client. It is a no-op when the client could not be created.
+func (g *googleClient) Close() error {
+	if g == nil || g.client == nil {
+		return nil
+	}
+
+	return g.client.Close()
+}
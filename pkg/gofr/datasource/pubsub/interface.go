@@ -23,6 +23,8 @@ type Client interface {
 
 	CreateTopic(context context.Context, name string) error
 	DeleteTopic(context context.Context, name string) error
+
+	Close() error
 }
 
 type Committer interface {
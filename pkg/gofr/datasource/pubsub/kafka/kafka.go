@@ -157,6 +157,10 @@ func (k *kafkaClient) Subscribe(ctx context.Context, topic string) (*pubsub.Mess
 }
 
 func (k *kafkaClient) Close() error {
+	if k == nil {
+		return nil
+	}
+
 	err := k.writer.Close()
 	if err != nil {
 		k.logger.Errorf("failed to close Kafka writer: %v", err)
@@ -164,6 +168,18 @@ func (k *kafkaClient) Close() error {
 		return err
 	}
 
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for topic, reader := range k.reader {
+		err = reader.Close()
+		if err != nil {
+			k.logger.Errorf("failed to close Kafka reader for topic %s: %v", topic, err)
+
+			return err
+		}
+	}
+
 	return nil
 }
 
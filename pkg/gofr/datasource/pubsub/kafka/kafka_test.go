@@ -226,9 +226,11 @@ func TestKafkaClient_Close(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockWriter := NewMockWriter(ctrl)
-	k := kafkaClient{writer: mockWriter}
+	mockReader := NewMockReader(ctrl)
+	k := kafkaClient{writer: mockWriter, reader: map[string]Reader{"test": mockReader}, mu: &sync.RWMutex{}}
 
 	mockWriter.EXPECT().Close().Return(nil)
+	mockReader.EXPECT().Close().Return(nil)
 
 	err := k.Close()
 
@@ -245,7 +247,7 @@ func TestKafkaClient_CloseError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockWriter := NewMockWriter(ctrl)
-	k := kafkaClient{writer: mockWriter}
+	k := kafkaClient{writer: mockWriter, mu: &sync.RWMutex{}}
 
 	mockWriter.EXPECT().Close().Return(errClose)
 
@@ -3,6 +3,9 @@ package pubsub
 import (
 	"context"
 	"encoding/json"
+	"time"
+
+	"gofr.dev/pkg/gofr/internal/params"
 )
 
 type Message struct {
@@ -35,6 +38,49 @@ func (m *Message) Param(p string) string {
 	return ""
 }
 
+// Params returns the value of the parameter p as the only value of the list, which is only set for the topic.
+func (m *Message) Params(p string) []string {
+	if v := m.Param(p); v != "" {
+		return []string{v}
+	}
+
+	return nil
+}
+
+// ParamInt returns the value of the parameter p as an int. The typed getters of messages return ErrorMissingParam and
+// ErrorInvalidParam of the gofr/http package, the same errors as the ones of the requests of the other transports.
+func (m *Message) ParamInt(p string) (int, error) {
+	return params.Int(p, m.Param(p))
+}
+
+func (m *Message) ParamFloat(p string) (float64, error) {
+	return params.Float(p, m.Param(p))
+}
+
+func (m *Message) ParamBool(p string) (bool, error) {
+	return params.Bool(p, m.Param(p))
+}
+
+// ParamTime returns the value of the parameter p as a time in the RFC 3339 format.
+func (m *Message) ParamTime(p string) (time.Time, error) {
+	return params.Time(p, m.Param(p))
+}
+
+// Header returns the value of the header with the given key when the MetaData of the message holds its headers,
+// as a map[string]string, and an empty string otherwise.
+func (m *Message) Header(key string) string {
+	if headers, ok := m.MetaData.(map[string]string); ok {
+		return headers[key]
+	}
+
+	return ""
+}
+
+// Cookie returns an empty string, as messages do not have cookies.
+func (*Message) Cookie(string) string {
+	return ""
+}
+
 func (m *Message) PathParam(p string) string {
 	return m.Param(p)
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/internal/params"
 )
 
 func TestMessage_Context(t *testing.T) {
@@ -96,3 +98,32 @@ func TestMessage_HostName(t *testing.T) {
 
 	assert.Equal(t, "", out)
 }
+
+func TestMessage_TypedParams(t *testing.T) {
+	m := NewMessage(context.Background())
+	m.Topic = "42"
+
+	n, err := m.ParamInt("topic")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 42, n)
+	assert.Equal(t, []string{"42"}, m.Params("topic"))
+	assert.Nil(t, m.Params("path"))
+
+	_, err = m.ParamBool("topic")
+	assert.Equal(t, params.ErrorInvalidParam{Params: []string{"topic"}}, err)
+
+	_, err = m.ParamTime("path")
+	assert.Equal(t, params.ErrorMissingParam{Params: []string{"path"}}, err)
+}
+
+func TestMessage_Header(t *testing.T) {
+	m := NewMessage(context.Background())
+
+	assert.Equal(t, "", m.Header("key"))
+
+	m.MetaData = map[string]string{"key": "value"}
+
+	assert.Equal(t, "value", m.Header("key"))
+	assert.Equal(t, "", m.Cookie("key"))
+}
@@ -17,6 +17,8 @@ import (
 const (
 	publicBroker  = "broker.hivemq.com"
 	messageBuffer = 10
+	// quiesceTime is the time in milliseconds given to the client to complete pending work on Close.
+	quiesceTime = 250
 )
 
 var errClientNotConnected = errors.New("client not connected")
@@ -183,7 +185,12 @@ func (m *MQTT) Subscribe(ctx context.Context, topic string) (*pubsub.Message, er
 	m.metrics.IncrementCounter(ctx, "app_pubsub_subscribe_success_count", "topic", topic)
 
 	// blocks if there are no messages in the channel
-	return <-msgChan, nil
+	select {
+	case msg := <-msgChan:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 func (m *MQTT) Publish(ctx context.Context, topic string, message []byte) error {
@@ -300,6 +307,17 @@ func (m *MQTT) Disconnect(waitTime uint) {
 	m.Client.Disconnect(waitTime)
 }
 
+// Close disconnects the client from the broker after giving it quiesceTime to complete pending work.
+func (m *MQTT) Close() error {
+	if m.Client == nil {
+		return nil
+	}
+
+	m.Client.Disconnect(quiesceTime)
+
+	return nil
+}
+
 func (m *MQTT) Ping() error {
 	connected := m.Client.IsConnected()
 
@@ -75,6 +75,15 @@ func NewClient(c config.Config, logger datasource.Logger, metrics Metrics) *Redi
 	return &Redis{Client: rc, config: redisConfig, logger: logger}
 }
 
+// Close closes the redis client. It is a no-op when the connection could not be established.
+func (r *Redis) Close() error {
+	if r == nil || r.Client == nil {
+		return nil
+	}
+
+	return r.Client.Close()
+}
+
 // TODO - if we make Redis an interface and expose from container we can avoid c.Redis(c, command) using methods on c and still pass c.
 // type Redis interface {
 //	Get(string) (string, error)
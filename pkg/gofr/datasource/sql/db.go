@@ -104,6 +104,15 @@ func (d *DB) Begin() (*Tx, error) {
 	return &Tx{Tx: tx, logger: d.logger, metrics: d.metrics}, nil
 }
 
+// Close closes the database connection pool. It is a no-op when the connection could not be established.
+func (d *DB) Close() error {
+	if d == nil || d.DB == nil {
+		return nil
+	}
+
+	return d.DB.Close()
+}
+
 type Tx struct {
 	*sql.Tx
 	logger  datasource.Logger
@@ -1,7 +1,12 @@
 package gofr
 
+import "time"
+
 const (
 	defaultHTTPPort   = 8000
 	defaultGRPCPort   = 9000
 	defaultMetricPort = 2121
+
+	defaultShutdownGracePeriod = 30 * time.Second
+	defaultWebSocketPingPeriod = 30 * time.Second
 )
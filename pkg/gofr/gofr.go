@@ -2,12 +2,16 @@ package gofr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -22,11 +26,14 @@ import (
 	"gofr.dev/pkg/gofr/config"
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/datasource"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/http/middleware"
 	"gofr.dev/pkg/gofr/logging"
 	"gofr.dev/pkg/gofr/metrics"
 	"gofr.dev/pkg/gofr/migration"
+	"gofr.dev/pkg/gofr/openapi"
 	"gofr.dev/pkg/gofr/service"
+	"gofr.dev/pkg/gofr/validation"
 )
 
 // App is the main application in the gofr framework.
@@ -47,6 +54,21 @@ type App struct {
 	httpRegistered bool
 
 	subscriptionManager SubscriptionManager
+	cron                *crontab
+
+	// routes are the HTTP routes documented in the OpenAPI document of the App.
+	routes []openapi.Route
+
+	onStartHooks []func(*Context) error
+	onStopHooks  []func(*Context) error
+
+	shutdownOnce sync.Once
+
+	// shutdownStarted is set once Shutdown is called, and shutdownDone is closed once it completes, for Run to
+	// return only after the OnStop hooks are run and the datasources are closed.
+	shutdownStarted  atomic.Bool
+	shutdownDone     chan struct{}
+	shutdownDoneOnce sync.Once
 }
 
 // RegisterService adds a grpc service to the gofr application.
@@ -70,7 +92,7 @@ func New() *App {
 		port = defaultMetricPort
 	}
 
-	app.metricServer = newMetricServer(port)
+	app.metricServer = newMetricServer(app.container, port)
 
 	// HTTP Server
 	port, err = strconv.Atoi(app.Config.Get("HTTP_PORT"))
@@ -78,7 +100,12 @@ func New() *App {
 		port = defaultHTTPPort
 	}
 
-	app.httpServer = newHTTPServer(app.container, port)
+	httpTLS := newTLSConfig(readTLSFiles(app.Config, "HTTP"), []string{"h2", "http/1.1"}, app.container.Logger)
+
+	app.httpServer = newHTTPServer(app.container, port, httpTLS)
+	app.httpServer.requestTimeout = app.requestTimeout()
+	app.httpServer.cacheStore = app.cacheStore()
+	app.SetCORS(app.corsConfig())
 
 	// GRPC Server
 	port, err = strconv.Atoi(app.Config.Get("GRPC_PORT"))
@@ -86,9 +113,12 @@ func New() *App {
 		port = defaultGRPCPort
 	}
 
-	app.grpcServer = newGRPCServer(app.container, port)
+	grpcTLS := newTLSConfig(readTLSFiles(app.Config, "GRPC"), []string{"h2"}, app.container.Logger)
+
+	app.grpcServer = newGRPCServer(app.container, port, grpcTLS)
 
 	app.subscriptionManager = newSubscriptionManager(app.container)
+	app.cron = newCrontab(app.container)
 
 	return app
 }
@@ -109,11 +139,37 @@ func NewCMD() *App {
 }
 
 // Run starts the application. If it is a HTTP server, it will start the server.
+// On receiving SIGINT or SIGTERM, the application is shut down gracefully using Shutdown, and Run returns once
+// Shutdown completes.
 func (a *App) Run() {
+	if err := a.runOnStartHooks(context.Background()); err != nil {
+		a.container.Logger.Errorf("OnStart hook failed, not starting the application: %v", err)
+
+		return
+	}
+
 	if a.cmd != nil {
 		a.cmd.Run(a.container)
+
+		if err := a.runOnStopHooks(context.Background()); err != nil {
+			a.container.Logger.Errorf("OnStop hook failed: %v", err)
+		}
+
+		return
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.shutdownGracePeriod())
+		defer cancel()
+
+		_ = a.Shutdown(shutdownCtx)
+	}()
+
 	wg := sync.WaitGroup{}
 
 	// Start Metrics Server
@@ -133,11 +189,29 @@ func (a *App) Run() {
 		a.add(http.MethodGet, "/.well-known/health", healthHandler)
 		a.add(http.MethodGet, "/.well-known/alive", liveHandler)
 		a.add(http.MethodGet, "/favicon.ico", faviconHandler)
+		a.add(http.MethodGet, openAPIPath, a.openAPIHandler)
+		a.add(http.MethodGet, swaggerUIPath, swaggerUIHandler)
+		a.add(http.MethodGet, swaggerAssetPath, swaggerAssetHandler)
 		a.httpServer.router.PathPrefix("/").Handler(handler{
 			function:  catchAllHandler,
 			container: a.container,
+			envelope:  &a.httpServer.envelope,
 		})
 
+		// the rate limiter runs after the middlewares of the app, so that the clients can be identified by them.
+		if m := a.rateLimiter(); m != nil {
+			a.httpServer.router.UseMiddleware(m)
+		}
+
+		if m := a.compression(); m != nil {
+			a.httpServer.router.UseMiddleware(m)
+		}
+
+		// the responses replayed to the retries are stored before being compressed.
+		if m := a.idempotency(); m != nil {
+			a.httpServer.router.UseMiddleware(m)
+		}
+
 		go func(s *httpServer) {
 			defer wg.Done()
 			s.Run(a.container)
@@ -154,17 +228,122 @@ func (a *App) Run() {
 		}(a.grpcServer)
 	}
 
-	// If subscriber is registered, block main go routine to wait for subscriber to receive messages
+	// Start subscribers concurrently using go-routines, they are stopped during shutdown.
 	if len(a.subscriptionManager.subscriptions) != 0 {
-		// Start subscribers concurrently using go-routines
-		for topic, handler := range a.subscriptionManager.subscriptions {
-			go a.subscriptionManager.startSubscriber(topic, handler)
-		}
+		a.subscriptionManager.start()
+	}
 
-		wg.Add(1)
+	// Start the cron scheduler, it is stopped during shutdown.
+	if len(a.cron.jobs) != 0 {
+		a.cron.start()
 	}
 
 	wg.Wait()
+
+	// the servers stop as soon as Shutdown is called, which then goes on with the subscribers, the OnStop hooks and
+	// the datasources.
+	if a.shutdownStarted.Load() {
+		<-a.done()
+	}
+}
+
+// Shutdown gracefully stops the application. The HTTP and gRPC servers stop accepting new requests and wait for
+// the in-flight ones, the subscribers stop after the message being processed, the OnStop hooks are run and then
+// the datasources in the container are closed. The metrics server is stopped last. If ctx is done before the servers and subscribers
+// have stopped, the remaining work is abandoned. Shutdown returns the errors encountered while stopping.
+func (a *App) Shutdown(ctx context.Context) error {
+	var err error
+
+	a.shutdownOnce.Do(func() {
+		a.shutdownStarted.Store(true)
+		defer close(a.done())
+
+		err = a.shutdown(ctx)
+	})
+
+	return err
+}
+
+// done returns the channel closed once Shutdown completes.
+func (a *App) done() chan struct{} {
+	a.shutdownDoneOnce.Do(func() {
+		a.shutdownDone = make(chan struct{})
+	})
+
+	return a.shutdownDone
+}
+
+func (a *App) shutdown(ctx context.Context) error {
+	var err error
+
+	a.container.Logger.Info("shutting down the application")
+
+	if a.httpServer != nil {
+		err = errors.Join(err, a.httpServer.Shutdown(ctx))
+	}
+
+	if a.grpcServer != nil {
+		err = errors.Join(err, a.grpcServer.Shutdown(ctx))
+	}
+
+	err = errors.Join(err, a.subscriptionManager.shutdown(ctx))
+
+	if a.cron != nil {
+		err = errors.Join(err, a.cron.shutdown(ctx))
+	}
+
+	err = errors.Join(err, a.runOnStopHooks(ctx))
+
+	err = errors.Join(err, a.container.Close())
+
+	if a.metricServer != nil {
+		err = errors.Join(err, a.metricServer.Shutdown(ctx))
+	}
+
+	if err != nil {
+		a.container.Logger.Errorf("error while shutting down the application: %v", err)
+
+		return err
+	}
+
+	a.container.Logger.Info("application shutdown complete")
+
+	return nil
+}
+
+// shutdownGracePeriod returns the time given to the application to shut down, read from SHUTDOWN_GRACE_PERIOD.
+func (a *App) shutdownGracePeriod() time.Duration {
+	value := a.Config.Get("SHUTDOWN_GRACE_PERIOD")
+	if value == "" {
+		return defaultShutdownGracePeriod
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil || period <= 0 {
+		a.container.Logger.Errorf("invalid value %q for SHUTDOWN_GRACE_PERIOD, using default of %v", value, defaultShutdownGracePeriod)
+
+		return defaultShutdownGracePeriod
+	}
+
+	return period
+}
+
+// requestTimeout returns the time given to the handlers to complete, read from REQUEST_TIMEOUT. It is 0, for the
+// handlers to have no timeout, when it is not set.
+func (a *App) requestTimeout() time.Duration {
+	value := a.Config.Get("REQUEST_TIMEOUT")
+	if value == "" {
+		return 0
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		a.container.Logger.Errorf("invalid value %q for REQUEST_TIMEOUT, the requests have no timeout", value)
+
+		return 0
+	}
+
+	return timeout
 }
 
 // readConfig reads the configuration from the default location.
@@ -196,32 +375,98 @@ func (a *App) AddHTTPService(serviceName, serviceAddress string, options ...serv
 	a.container.Services[serviceName] = service.NewHTTPService(serviceAddress, a.container.Logger, a.container.Metrics(), options...)
 }
 
+// AddValidator registers a custom validation rule named tag, which can then be used in the validate tags of the
+// structs bound to the requests like the built-in rules, e.g.
+//
+//	app.AddValidator("sku", func(value interface{}, _ string) bool {
+//		s, ok := value.(string)
+//		return ok && strings.HasPrefix(s, "SKU-")
+//	})
+//
+// The param of the validation function is the parameter of the rule in the tag, like 10 in min=10.
+func (a *App) AddValidator(tag string, fn validation.Func) {
+	a.container.Validator().Register(tag, fn)
+}
+
+// SetResponseEnvelope sets the Envelope creating the body of the responses of the HTTP handlers, e.g.
+//
+//	app.SetResponseEnvelope(gofrHTTP.BareEnvelope) // responds with the data returned by the handlers as it is
+//	app.SetResponseEnvelope(gofrHTTP.ProblemDetailsEnvelope(gofrHTTP.DefaultEnvelope)) // responds to errors using RFC 7807
+//
+// The default envelope wraps the data in the data field of the responses and the errors in their error field.
+func (a *App) SetResponseEnvelope(e gofrHTTP.Envelope) {
+	a.httpServer.envelope = e
+	a.httpServer.router.SetEnvelope(e)
+}
+
 // GET adds a Handler for http GET method for a route pattern.
-func (a *App) GET(pattern string, handler Handler) {
-	a.add("GET", pattern, handler)
+func (a *App) GET(pattern string, handler Handler, options ...RouteOption) {
+	a.add("GET", pattern, handler, options...)
 }
 
 // PUT adds a Handler for http PUT method for a route pattern.
-func (a *App) PUT(pattern string, handler Handler) {
-	a.add("PUT", pattern, handler)
+func (a *App) PUT(pattern string, handler Handler, options ...RouteOption) {
+	a.add("PUT", pattern, handler, options...)
 }
 
 // POST adds a Handler for http POST method for a route pattern.
-func (a *App) POST(pattern string, handler Handler) {
-	a.add("POST", pattern, handler)
+func (a *App) POST(pattern string, handler Handler, options ...RouteOption) {
+	a.add("POST", pattern, handler, options...)
 }
 
 // DELETE adds a Handler for http DELETE method for a route pattern.
-func (a *App) DELETE(pattern string, handler Handler) {
-	a.add("DELETE", pattern, handler)
+func (a *App) DELETE(pattern string, handler Handler, options ...RouteOption) {
+	a.add("DELETE", pattern, handler, options...)
+}
+
+// PATCH adds a Handler for http PATCH method for a route pattern.
+func (a *App) PATCH(pattern string, handler Handler, options ...RouteOption) {
+	a.add("PATCH", pattern, handler, options...)
+}
+
+// HEAD adds a Handler for http HEAD method for a route pattern. It is only needed when HEAD requests have to be
+// handled differently, as they are otherwise answered by the GET handler of the pattern without a response body.
+func (a *App) HEAD(pattern string, handler Handler, options ...RouteOption) {
+	a.add("HEAD", pattern, handler, options...)
 }
 
-func (a *App) add(method, pattern string, h Handler) {
+// OPTIONS adds a Handler for http OPTIONS method for a route pattern.
+func (a *App) OPTIONS(pattern string, handler Handler, options ...RouteOption) {
+	a.add("OPTIONS", pattern, handler, options...)
+}
+
+// Any adds a Handler for all the http methods for a route pattern. Routes added for a specific method
+// take precedence when they are added before.
+func (a *App) Any(pattern string, handler Handler, options ...RouteOption) {
+	a.add("", pattern, handler, options...)
+}
+
+func (a *App) add(method, pattern string, h Handler, options ...RouteOption) {
+	a.addRoute(a.httpServer.router, method, pattern, pattern, h, options)
+}
+
+// addRoute adds the route for pattern to router, recording it with its full path for the OpenAPI document.
+// The route is restricted to the media types given using MediaTypes, if any.
+func (a *App) addRoute(router *gofrHTTP.Router, method, path, pattern string, h Handler, options []RouteOption) {
+	route, cfg := newRoute(method, path, options)
+
+	timeout := a.httpServer.requestTimeout
+	if cfg.timeout != 0 {
+		timeout = cfg.timeout
+	}
+
 	a.httpRegistered = true
-	a.httpServer.router.Add(method, pattern, handler{
-		function:  h,
-		container: a.container,
+	router.Add(method, pattern, handler{
+		function:   h,
+		container:  a.container,
+		envelope:   &a.httpServer.envelope,
+		mediaTypes: cfg.mediaTypes,
+		timeout:    timeout,
+		cache:      cfg.cache,
+		cacheStore: a.httpServer.cacheStore,
 	})
+
+	a.routes = append(a.routes, route)
 }
 
 func (a *App) Metrics() metrics.Manager {
@@ -296,17 +541,36 @@ func (o *otelErrorHandler) Handle(e error) {
 	o.logger.Error(e.Error())
 }
 
-func (a *App) EnableBasicAuth(credentials ...string) {
-	if len(credentials)%2 != 0 {
-		a.container.Error("Invalid number of arguments for EnableBasicAuth")
+// UseMiddleware adds middlewares to all the HTTP routes of the app. They run after the built-in middlewares
+// (tracing, logging, CORS, metrics and panic recovery), in the order in which they are added along with the
+// authentication middlewares enabled on the app, and before the middlewares of route groups.
+func (a *App) UseMiddleware(middlewares ...func(http.Handler) http.Handler) {
+	a.httpServer.router.UseMiddleware(middlewares...)
+}
+
+// ReplaceMiddleware replaces a built-in middleware like gofrHTTP.CORSMiddleware with the given middleware,
+// which runs at the same position in the chain.
+func (a *App) ReplaceMiddleware(name gofrHTTP.BuiltinMiddleware, m func(http.Handler) http.Handler) {
+	if m == nil {
+		a.container.Errorf("middleware replacing %s is nil, use DisableMiddleware to disable it", name)
+
+		return
 	}
 
-	users := make(map[string]string)
-	for i := 0; i < len(credentials); i += 2 {
-		users[credentials[i]] = credentials[i+1]
+	if err := a.httpServer.router.ReplaceBuiltin(name, m); err != nil {
+		a.container.Errorf("could not replace middleware: %v", err)
+	}
+}
+
+// DisableMiddleware removes a built-in middleware like gofrHTTP.CORSMiddleware from the chain.
+func (a *App) DisableMiddleware(name gofrHTTP.BuiltinMiddleware) {
+	if err := a.httpServer.router.ReplaceBuiltin(name, nil); err != nil {
+		a.container.Errorf("could not disable middleware: %v", err)
 	}
+}
 
-	a.httpServer.router.Use(middleware.BasicAuthMiddleware(middleware.BasicAuthProvider{Users: users}))
+func (a *App) EnableBasicAuth(credentials ...string) {
+	a.httpServer.router.Use(a.basicAuthMiddleware(credentials...))
 }
 
 func (a *App) EnableBasicAuthWithFunc(validateFunc func(username, password string) bool) {
@@ -322,14 +586,33 @@ func (a *App) EnableAPIKeyAuthWithFunc(validator func(apiKey string) bool) {
 }
 
 func (a *App) EnableOAuth(jwksEndpoint string, refreshInterval int) {
-	a.AddHTTPService("gofr_oauth", jwksEndpoint)
+	a.httpServer.router.Use(a.oAuthMiddleware("gofr_oauth", jwksEndpoint, refreshInterval))
+}
+
+func (a *App) basicAuthMiddleware(credentials ...string) func(http.Handler) http.Handler {
+	if len(credentials)%2 != 0 {
+		a.container.Error("Invalid number of arguments for EnableBasicAuth")
+	}
+
+	users := make(map[string]string)
+	for i := 0; i < len(credentials); i += 2 {
+		users[credentials[i]] = credentials[i+1]
+	}
+
+	return middleware.BasicAuthMiddleware(middleware.BasicAuthProvider{Users: users})
+}
+
+// oAuthMiddleware registers the JWKS endpoint as an HTTP service with the given name and creates the OAuth
+// middleware which validates the tokens using the keys fetched from it.
+func (a *App) oAuthMiddleware(serviceName, jwksEndpoint string, refreshInterval int) func(http.Handler) http.Handler {
+	a.AddHTTPService(serviceName, jwksEndpoint)
 
 	oauthOption := middleware.OauthConfigs{
-		Provider:        a.container.GetHTTPService("gofr_oauth"),
+		Provider:        a.container.GetHTTPService(serviceName),
 		RefreshInterval: time.Second * time.Duration(refreshInterval),
 	}
 
-	a.httpServer.router.Use(middleware.OAuth(middleware.NewOAuth(oauthOption)))
+	return middleware.OAuth(middleware.NewOAuth(oauthOption))
 }
 
 func (a *App) Subscribe(topic string, handler SubscribeFunc) {
@@ -342,7 +625,14 @@ func (a *App) Subscribe(topic string, handler SubscribeFunc) {
 	a.subscriptionManager.subscriptions[topic] = handler
 }
 
-func (a *App) AddRESTHandlers(object interface{}) error {
+// AddRESTHandlers adds the CRUD routes of the entity struct object, whose handlers can be overridden by the methods of
+// object. The options apply to the routes getting the entities, e.g. Cache to cache them:
+//
+//	err := app.AddRESTHandlers(&User{}, gofr.Cache(gofr.CacheConfig{TTL: time.Minute}))
+//
+// The cached responses are tagged with the name of the struct, and the ones of a single entity with name:{id} as well,
+// which are invalidated once an entity is created, updated or deleted by the default handlers.
+func (a *App) AddRESTHandlers(object interface{}, options ...RouteOption) error {
 	cfg, err := scanEntity(object)
 	if err != nil {
 		a.container.Logger.Errorf("invalid object for AddRESTHandlers")
@@ -352,7 +642,7 @@ func (a *App) AddRESTHandlers(object interface{}) error {
 
 	e := entity{cfg.name, cfg.entityType, cfg.primaryKey}
 
-	a.registerCRUDHandlers(e, object)
+	a.registerCRUDHandlers(e, object, options...)
 
 	return nil
 }
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -136,6 +138,166 @@ func TestGofr_ServerRun(t *testing.T) {
 	resp.Body.Close()
 }
 
+func TestGofr_Shutdown(t *testing.T) {
+	t.Setenv("HTTP_PORT", "8010")
+	t.Setenv("METRICS_PORT", "2130")
+
+	g := New()
+
+	g.GET("/hello", func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	runReturned := make(chan struct{})
+
+	go func() {
+		g.Run()
+		close(runReturned)
+	}()
+
+	time.Sleep(1 * time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := g.Shutdown(ctx)
+
+	assert.NoError(t, err, "TEST Failed.\n")
+
+	select {
+	case <-runReturned:
+	case <-time.After(5 * time.Second):
+		t.Error("TEST Failed.\nRun did not return after Shutdown")
+	}
+
+	var netClient = &http.Client{
+		Timeout: time.Second * 10,
+	}
+
+	re, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost:8010/hello", http.NoBody)
+
+	resp, err := netClient.Do(re)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	assert.Error(t, err, "TEST Failed.\nserver should not accept requests after Shutdown")
+}
+
+func TestGofr_RunWaitsForShutdown(t *testing.T) {
+	t.Setenv("HTTP_PORT", "8012")
+	t.Setenv("METRICS_PORT", "2132")
+
+	g := New()
+
+	g.GET("/hello", func(*Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	var hookDone atomic.Bool
+
+	g.OnStop(func(*Context) error {
+		time.Sleep(200 * time.Millisecond)
+		hookDone.Store(true)
+
+		return nil
+	})
+
+	runReturned := make(chan struct{})
+
+	go func() {
+		g.Run()
+		close(runReturned)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	go func() { _ = g.Shutdown(context.Background()) }()
+
+	select {
+	case <-runReturned:
+		assert.True(t, hookDone.Load(), "TEST Failed.\nRun returned before the OnStop hooks completed")
+	case <-time.After(5 * time.Second):
+		t.Error("TEST Failed.\nRun did not return after Shutdown")
+	}
+}
+
+func TestGofr_shutdownGracePeriod(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		value  string
+		expOut time.Duration
+	}{
+		{"not configured", "", defaultShutdownGracePeriod},
+		{"valid duration", "10s", 10 * time.Second},
+		{"invalid duration", "ten", defaultShutdownGracePeriod},
+		{"negative duration", "-5s", defaultShutdownGracePeriod},
+	}
+
+	for i, tc := range testCases {
+		a := &App{
+			Config:    testutil.NewMockConfig(map[string]string{"SHUTDOWN_GRACE_PERIOD": tc.value}),
+			container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		}
+
+		assert.Equal(t, tc.expOut, a.shutdownGracePeriod(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGofr_requestTimeout(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		value  string
+		expOut time.Duration
+	}{
+		{"not configured", "", 0},
+		{"valid duration", "10s", 10 * time.Second},
+		{"invalid duration", "ten", 0},
+		{"negative duration", "-5s", 0},
+	}
+
+	for i, tc := range testCases {
+		a := &App{
+			Config:    testutil.NewMockConfig(map[string]string{"REQUEST_TIMEOUT": tc.value}),
+			container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		}
+
+		assert.Equal(t, tc.expOut, a.requestTimeout(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_RouteTimeout(t *testing.T) {
+	a := newTestApp()
+	a.httpServer.requestTimeout = 20 * time.Millisecond
+
+	slow := func(*Context) (interface{}, error) {
+		time.Sleep(100 * time.Millisecond)
+
+		return "done", nil
+	}
+
+	a.GET("/default", slow)
+	a.GET("/longer", slow, Timeout(time.Second))
+	a.GET("/none", slow, Timeout(0))
+
+	tests := []struct {
+		desc       string
+		path       string
+		statusCode int
+	}{
+		{"timeout of the app", "/default", http.StatusGatewayTimeout},
+		{"timeout of the route", "/longer", http.StatusOK},
+		{"route without timeout", "/none", http.StatusOK},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
 func Test_AddHTTPService(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/test", r.URL.Path)
@@ -348,3 +510,213 @@ func Test_initTracer_invalidConfig(t *testing.T) {
 
 	assert.Contains(t, errLogMessage, "unsupported trace exporter.")
 }
+
+func TestApp_UseMiddleware(t *testing.T) {
+	a := newTestApp()
+
+	a.UseMiddleware(func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Tenant", r.Header.Get("X-Tenant-ID"))
+			inner.ServeHTTP(w, r)
+		})
+	})
+
+	a.DisableMiddleware(gofrHTTP.CORSMiddleware)
+
+	a.GET("/hello", func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/hello", http.NoBody)
+	r.Header.Set("X-Tenant-ID", "zopsmart")
+
+	a.httpServer.router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "zopsmart", w.Header().Get("X-Tenant"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestApp_ReplaceMiddlewareErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		name       gofrHTTP.BuiltinMiddleware
+		middleware func(http.Handler) http.Handler
+		expLog     string
+	}{
+		{"nil middleware", gofrHTTP.CORSMiddleware, nil, "use DisableMiddleware to disable it"},
+		{"unknown middleware", "gzip", func(h http.Handler) http.Handler { return h }, "unknown built-in middleware: gzip"},
+	}
+
+	for i, tc := range testCases {
+		logs := testutil.StderrOutputForFunc(func() {
+			a := newTestApp()
+
+			a.ReplaceMiddleware(tc.name, tc.middleware)
+		})
+
+		assert.Contains(t, logs, tc.expLog, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestGofr_AdditionalMethodRoutes(t *testing.T) {
+	a := newTestApp()
+
+	a.GET("/hello", func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	a.PATCH("/hello", func(c *Context) (interface{}, error) {
+		return "patched", nil
+	})
+
+	a.OPTIONS("/hello", func(c *Context) (interface{}, error) {
+		return nil, nil
+	})
+
+	a.GET("/custom-head", func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	a.HEAD("/custom-head", func(c *Context) (interface{}, error) {
+		return nil, testutil.CustomError{ErrorMessage: "head not supported"}
+	})
+
+	a.Any("/any", func(c *Context) (interface{}, error) {
+		return "any", nil
+	})
+
+	testCases := []struct {
+		method string
+		target string
+		status int
+		body   string
+	}{
+		{http.MethodPatch, "/hello", http.StatusOK, `{"data":"patched"}`},
+		{http.MethodHead, "/hello", http.StatusOK, ""},
+		{http.MethodOptions, "/hello", http.StatusNoContent, "{}"},
+		{http.MethodHead, "/custom-head", http.StatusInternalServerError, ""},
+		{http.MethodGet, "/custom-head", http.StatusOK, `{"data":"Hello World!"}`},
+		{http.MethodGet, "/any", http.StatusOK, `{"data":"any"}`},
+		{http.MethodPut, "/any", http.StatusOK, `{"data":"any"}`},
+		{http.MethodPost, "/any", http.StatusCreated, `{"data":"any"}`},
+		{http.MethodPut, "/hello", http.StatusMethodNotAllowed, ""},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, tc.target, http.NoBody)
+
+		a.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\nUnexpected status for %s %s.", i, tc.method, tc.target)
+		assert.Equal(t, tc.body, strings.TrimSpace(w.Body.String()),
+			"TEST[%d], Failed.\nUnexpected response for %s %s.", i, tc.method, tc.target)
+	}
+}
+
+func TestApp_SetResponseEnvelope(t *testing.T) {
+	a := newTestApp()
+
+	a.GET("/users/{id}", func(c *Context) (interface{}, error) {
+		if c.PathParam("id") != "1" {
+			return nil, gofrHTTP.ErrorEntityNotFound{Name: "id", Value: c.PathParam("id")}
+		}
+
+		return map[string]int{"id": 1}, nil
+	})
+
+	// the envelope applies to the routes added before it is set.
+	a.SetResponseEnvelope(gofrHTTP.ProblemDetailsEnvelope(gofrHTTP.BareEnvelope))
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":1}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/2", http.NoBody))
+
+	var problem map[string]interface{}
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "No entity found with id: 2", problem["detail"])
+	assert.Equal(t, "/users/2", problem["instance"])
+}
+
+func TestApp_PanicRecovery(t *testing.T) {
+	var w *httptest.ResponseRecorder
+
+	logs := testutil.StderrOutputForFunc(func() {
+		a := newTestApp()
+
+		a.GET("/panic", func(*Context) (interface{}, error) {
+			var user *struct{ Name string }
+
+			return user.Name, nil
+		})
+		a.GET("/timeout-panic", func(*Context) (interface{}, error) {
+			panic("handler failed")
+		}, Timeout(time.Second))
+
+		a.SetResponseEnvelope(gofrHTTP.ProblemDetailsEnvelope(gofrHTTP.DefaultEnvelope))
+
+		w = httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", http.NoBody))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"detail":"some unexpected error has occurred"`)
+
+		// the panics of the handlers having a timeout are recovered as well.
+		w = httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/timeout-panic", http.NoBody))
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, logs, "invalid memory address or nil pointer dereference")
+	assert.Contains(t, logs, "handler failed")
+}
+
+func TestApp_RouteMediaTypes(t *testing.T) {
+	a := newTestApp()
+
+	a.POST("/users", func(c *Context) (interface{}, error) {
+		var user struct {
+			Name string `xml:"name"`
+		}
+
+		if err := c.Bind(&user); err != nil {
+			return nil, err
+		}
+
+		return user.Name, nil
+	}, MediaTypes("application/xml"))
+
+	tests := []struct {
+		desc        string
+		contentType string
+		accept      string
+		body        string
+		statusCode  int
+	}{
+		{"supported media type", "application/xml", "", "<user><name>gofr</name></user>", http.StatusCreated},
+		{"unsupported media type", "application/json", "", `{"name": "gofr"}`, http.StatusUnsupportedMediaType},
+		{"not acceptable", "application/xml", "application/json", "<user><name>gofr</name></user>", http.StatusNotAcceptable},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		req.Header.Set("Accept", tc.accept)
+
+		w := httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,112 @@
+package gofr
+
+import (
+	"net/http"
+	"strings"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+// RouteGroup is a set of HTTP routes sharing a common path prefix. Middlewares added to a group only apply
+// to its own routes and to the routes of the groups nested inside it, e.g.
+//
+//	admin := app.Group("/v1/admin")
+//	admin.EnableBasicAuth("admin", "password")
+//	admin.GET("/users", listUsers) // serves /v1/admin/users
+type RouteGroup struct {
+	app    *App
+	prefix string
+	router *gofrHTTP.Router
+}
+
+// Group creates a RouteGroup for all the routes starting with the given prefix.
+func (a *App) Group(prefix string) *RouteGroup {
+	return &RouteGroup{
+		app:    a,
+		prefix: prefix,
+		router: a.httpServer.router.Group(prefix),
+	}
+}
+
+// Group creates a RouteGroup nested inside g, its prefix being appended to the prefix of g.
+func (g *RouteGroup) Group(prefix string) *RouteGroup {
+	return &RouteGroup{
+		app:    g.app,
+		prefix: g.prefix + prefix,
+		router: g.router.Group(prefix),
+	}
+}
+
+// Use adds middlewares which are applied only to the routes of the group, after the middlewares of the App.
+func (g *RouteGroup) Use(middlewares ...func(http.Handler) http.Handler) {
+	g.router.UseMiddleware(middlewares...)
+}
+
+// GET adds a Handler for http GET method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) GET(pattern string, handler Handler, options ...RouteOption) {
+	g.add("GET", pattern, handler, options...)
+}
+
+// PUT adds a Handler for http PUT method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) PUT(pattern string, handler Handler, options ...RouteOption) {
+	g.add("PUT", pattern, handler, options...)
+}
+
+// POST adds a Handler for http POST method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) POST(pattern string, handler Handler, options ...RouteOption) {
+	g.add("POST", pattern, handler, options...)
+}
+
+// DELETE adds a Handler for http DELETE method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) DELETE(pattern string, handler Handler, options ...RouteOption) {
+	g.add("DELETE", pattern, handler, options...)
+}
+
+// PATCH adds a Handler for http PATCH method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) PATCH(pattern string, handler Handler, options ...RouteOption) {
+	g.add("PATCH", pattern, handler, options...)
+}
+
+// HEAD adds a Handler for http HEAD method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) HEAD(pattern string, handler Handler, options ...RouteOption) {
+	g.add("HEAD", pattern, handler, options...)
+}
+
+// OPTIONS adds a Handler for http OPTIONS method for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) OPTIONS(pattern string, handler Handler, options ...RouteOption) {
+	g.add("OPTIONS", pattern, handler, options...)
+}
+
+// Any adds a Handler for all the http methods for a route pattern relative to the prefix of the group.
+func (g *RouteGroup) Any(pattern string, handler Handler, options ...RouteOption) {
+	g.add("", pattern, handler, options...)
+}
+
+func (g *RouteGroup) EnableBasicAuth(credentials ...string) {
+	g.router.Use(g.app.basicAuthMiddleware(credentials...))
+}
+
+func (g *RouteGroup) EnableBasicAuthWithFunc(validateFunc func(username, password string) bool) {
+	g.router.Use(middleware.BasicAuthMiddleware(middleware.BasicAuthProvider{ValidateFunc: validateFunc}))
+}
+
+func (g *RouteGroup) EnableAPIKeyAuth(apiKeys ...string) {
+	g.router.Use(middleware.APIKeyAuthMiddleware(nil, apiKeys...))
+}
+
+func (g *RouteGroup) EnableAPIKeyAuthWithFunc(validator func(apiKey string) bool) {
+	g.router.Use(middleware.APIKeyAuthMiddleware(validator))
+}
+
+// EnableOAuth validates the tokens of the requests to the group using the keys fetched from the JWKS endpoint.
+func (g *RouteGroup) EnableOAuth(jwksEndpoint string, refreshInterval int) {
+	// every group gets its own JWKS service, so that groups can use different identity providers.
+	serviceName := "gofr_oauth" + strings.ReplaceAll(g.prefix, "/", "_")
+
+	g.router.Use(g.app.oAuthMiddleware(serviceName, jwksEndpoint, refreshInterval))
+}
+
+func (g *RouteGroup) add(method, pattern string, h Handler, options ...RouteOption) {
+	g.app.addRoute(g.router, method, g.prefix+pattern, pattern, h, options)
+}
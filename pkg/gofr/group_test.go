@@ -0,0 +1,162 @@
+package gofr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func newTestApp() *App {
+	conf := testutil.NewMockConfig(nil)
+	c := container.NewContainer(conf)
+
+	return &App{
+		Config: conf,
+		httpServer: &httpServer{
+			router: gofrHTTP.NewRouter(c),
+		},
+		container: c,
+	}
+}
+
+func TestRouteGroup_Routes(t *testing.T) {
+	type response struct {
+		Data interface{} `json:"data"`
+	}
+
+	a := newTestApp()
+
+	hello := func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	}
+
+	a.GET("/hello", hello)
+
+	v1 := a.Group("/v1")
+	v1.GET("/hello", hello)
+	v1.PUT("/hello", hello)
+	v1.POST("/hello", hello)
+	v1.DELETE("/hello", hello)
+
+	users := v1.Group("/users")
+	users.GET("/{id}", func(c *Context) (interface{}, error) {
+		return c.PathParam("id"), nil
+	})
+
+	testCases := []struct {
+		method   string
+		target   string
+		status   int
+		response interface{}
+	}{
+		{http.MethodGet, "/hello", http.StatusOK, helloWorld},
+		{http.MethodGet, "/v1/hello", http.StatusOK, helloWorld},
+		{http.MethodPut, "/v1/hello", http.StatusOK, helloWorld},
+		{http.MethodPost, "/v1/hello", http.StatusCreated, helloWorld},
+		{http.MethodDelete, "/v1/hello", http.StatusNoContent, helloWorld},
+		{http.MethodGet, "/v1/users/42", http.StatusOK, "42"},
+		{http.MethodGet, "/v2/hello", http.StatusNotFound, nil},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(tc.method, tc.target, http.NoBody)
+
+		a.httpServer.router.ServeHTTP(w, r)
+
+		var res response
+
+		respBytes, _ := io.ReadAll(w.Body)
+		_ = json.Unmarshal(respBytes, &res)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\nUnexpected status for %s %s.", i, tc.method, tc.target)
+		assert.Equal(t, tc.response, res.Data, "TEST[%d], Failed.\nUnexpected response for %s %s.", i, tc.method, tc.target)
+	}
+
+	assert.True(t, a.httpRegistered)
+}
+
+func TestRouteGroup_Middlewares(t *testing.T) {
+	a := newTestApp()
+
+	hello := func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	}
+
+	a.GET("/public", hello)
+
+	admin := a.Group("/admin")
+	admin.EnableBasicAuth("admin", "password")
+	admin.GET("/users", hello)
+
+	reports := admin.Group("/reports")
+	reports.Use(func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Report", "true")
+			inner.ServeHTTP(w, r)
+		})
+	})
+	reports.GET("/daily", hello)
+
+	credentials := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:password"))
+
+	testCases := []struct {
+		desc          string
+		target        string
+		authorization string
+		status        int
+		reportHeader  string
+	}{
+		{"public route is not authenticated", "/public", "", http.StatusOK, ""},
+		{"group route requires credentials", "/admin/users", "", http.StatusUnauthorized, ""},
+		{"group route with credentials", "/admin/users", credentials, http.StatusOK, ""},
+		{"nested group inherits parent middleware", "/admin/reports/daily", "", http.StatusUnauthorized, ""},
+		{"nested group applies its own middleware", "/admin/reports/daily", credentials, http.StatusOK, "true"},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)
+
+		if tc.authorization != "" {
+			r.Header.Set("Authorization", tc.authorization)
+		}
+
+		a.httpServer.router.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.reportHeader, w.Header().Get("X-Report"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRouteGroup_PathTemplate(t *testing.T) {
+	a := newTestApp()
+
+	var template string
+
+	// the path template seen by the middlewares of the App is used as the label of the app_http_response metric.
+	a.httpServer.router.Use(func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			template, _ = mux.CurrentRoute(r).GetPathTemplate()
+			inner.ServeHTTP(w, r)
+		})
+	})
+
+	a.Group("/v1").Group("/users").GET("/{id}", func(c *Context) (interface{}, error) {
+		return nil, nil
+	})
+
+	a.httpServer.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/42", http.NoBody))
+
+	assert.Equal(t, "/v1/users/{id}", template)
+}
@@ -1,6 +1,8 @@
 package gofr
 
 import (
+	"context"
+	"crypto/tls"
 	"net"
 	"strconv"
 
@@ -8,6 +10,7 @@ import (
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc2 "gofr.dev/pkg/gofr/grpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"gofr.dev/pkg/gofr/container"
 )
@@ -17,14 +20,22 @@ type grpcServer struct {
 	port   int
 }
 
-func newGRPCServer(c *container.Container, port int) *grpcServer {
+// newGRPCServer creates the gRPC server of the application. The server uses TLS when tlsConfig is not nil.
+func newGRPCServer(c *container.Container, port int, tlsConfig *tls.Config) *grpcServer {
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			grpc_recovery.UnaryServerInterceptor(),
+			grpc2.LoggingInterceptor(c.Logger),
+		)),
+	}
+
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
 	return &grpcServer{
-		server: grpc.NewServer(
-			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-				grpc_recovery.UnaryServerInterceptor(),
-				grpc2.LoggingInterceptor(c.Logger),
-			))),
-		port: port,
+		server: grpc.NewServer(options...),
+		port:   port,
 	}
 }
 
@@ -44,3 +55,27 @@ func (g *grpcServer) Run(c *container.Container) {
 		return
 	}
 }
+
+// Shutdown stops the server from accepting new connections and RPCs and waits for the pending RPCs to complete.
+// If ctx is done before that, the server is stopped forcefully.
+func (g *grpcServer) Shutdown(ctx context.Context) error {
+	if g.server == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		g.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		g.server.Stop()
+
+		return ctx.Err()
+	}
+}
@@ -16,7 +16,7 @@ func TestNewGRPCServer(t *testing.T) {
 		Logger: logging.NewLogger(logging.DEBUG),
 	}
 
-	g := newGRPCServer(&c, 9999)
+	g := newGRPCServer(&c, 9999, nil)
 
 	assert.NotNil(t, g, "TEST Failed.\n")
 }
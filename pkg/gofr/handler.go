@@ -1,12 +1,18 @@
 package gofr
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
 	"gofr.dev/pkg/gofr/container"
 	gofrHTTP "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/http/response"
 	"gofr.dev/pkg/gofr/static"
-
-	"net/http"
 )
 
 type Handler func(c *Context) (interface{}, error)
@@ -27,12 +33,166 @@ for now. In the future, this can be considered as well if we are writing our own
 type handler struct {
 	function  Handler
 	container *container.Container
+
+	// envelope points to the Envelope of the App, so that it applies to the routes added before it is set.
+	envelope *gofrHTTP.Envelope
+
+	// mediaTypes are the media types the route is restricted to, if any.
+	mediaTypes []string
+
+	// timeout is the time given to the function to complete, no timeout being applied when it is not positive.
+	timeout time.Duration
+
+	// cache configures the caching of the responses to the GET and HEAD requests, which is not enabled when it is nil.
+	cache *CacheConfig
+
+	// cacheStore holds the cached responses of the app, which the function can invalidate.
+	cacheStore cacheStore
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	c := newContext(gofrHTTP.NewResponder(w, r.Method), gofrHTTP.NewRequest(r), h.container)
+	if h.cache != nil && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		h.serveCached(w, r)
+
+		return
+	}
+
+	h.serve(w, r)
+}
+
+func (h handler) serve(w http.ResponseWriter, r *http.Request) {
+	// the responses are written with the request itself, so that the streamed responses are not bounded by the timeout.
+	responder := gofrHTTP.NewResponder(w, r.Method).WithRequest(r).WithMediaTypes(h.mediaTypes)
+	if h.envelope != nil {
+		responder.WithEnvelope(*h.envelope)
+	}
+
+	if err := gofrHTTP.CheckMediaTypes(r, h.mediaTypes); err != nil {
+		responder.Respond(nil, err)
+
+		return
+	}
+
+	if h.timeout <= 0 {
+		c := h.newContext(responder, r)
+		defer c.Trace("gofr-handler").End()
+
+		c.responder.Respond(h.function(c))
+
+		return
+	}
+
+	h.serveWithTimeout(responder, r)
+}
+
+func (h handler) newContext(responder *gofrHTTP.Responder, r *http.Request) *Context {
+	c := newContext(responder, gofrHTTP.NewRequest(r), h.container)
+	c.cache = h.cacheStore
+
+	return c
+}
+
+type handlerResult struct {
+	data  interface{}
+	err   error
+	panic interface{}
+	stack []byte
+}
+
+// serveWithTimeout runs the function in its own goroutine with the deadline of its timeout, responding with
+// ErrorRequestTimeout once it passes. The function is expected to return soon after, as its calls see the deadline of
+// the context, but its result is not responded with then. The body of the request can not be read by the function
+// after the timeout, as the request is done with by then.
+//
+// The goroutines started by the function to produce streamed responses are given the context of the request without
+// the deadline by Context.StreamContext, as the responses are written after the function returns.
+func (h handler) serveWithTimeout(responder *gofrHTTP.Responder, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	req := r.WithContext(ctx)
+
+	body := &timeoutBody{ReadCloser: r.Body}
+	if r.Body != nil && r.Body != http.NoBody {
+		req.Body = body
+	}
+
+	c := h.newContext(responder, req)
+	c.streamCtx = r.Context()
+
 	defer c.Trace("gofr-handler").End()
-	c.responder.Respond(h.function(c))
+
+	done := make(chan handlerResult, 1)
+
+	go func() {
+		var res handlerResult
+
+		defer func() {
+			// the panics are raised again in the goroutine serving the request, for them to be recovered there.
+			if res.panic = recover(); res.panic != nil {
+				res.stack = debug.Stack()
+			}
+
+			done <- res
+		}()
+
+		res.data, res.err = h.function(c)
+	}()
+
+	select {
+	case res := <-done:
+		if res.panic != nil {
+			panic(res.panic)
+		}
+
+		// the errors caused by the timeout are the timeout of the request, rather than a timeout of the calls made by
+		// the function.
+		if timedOut(ctx) && (errors.Is(res.err, context.Canceled) || errors.Is(res.err, context.DeadlineExceeded)) {
+			res.err = gofrHTTP.ErrorRequestTimeout{}
+		}
+
+		c.responder.Respond(res.data, res.err)
+	case <-ctx.Done():
+		body.close()
+
+		go h.logLatePanic(r, done)
+
+		// nothing is responded to the clients which went away.
+		if timedOut(ctx) {
+			c.responder.Respond(nil, gofrHTTP.ErrorRequestTimeout{})
+		}
+	}
+}
+
+// logLatePanic logs the panic of a function which returns after its request is responded to, which can not be
+// recovered by the Recovery middleware anymore.
+func (h handler) logLatePanic(r *http.Request, done <-chan handlerResult) {
+	if res := <-done; res.panic != nil {
+		h.container.Errorf("panic in %v %v after the request timed out: %v\n%s", r.Method, r.URL.Path, res.panic, res.stack)
+	}
+}
+
+func timedOut(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// timeoutBody is the body of a request served with a timeout, which can not be read anymore once the request timed
+// out, as the server is done with the request then.
+type timeoutBody struct {
+	io.ReadCloser
+	closed atomic.Bool
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	if b.closed.Load() {
+		return 0, http.ErrBodyReadAfterClose
+	}
+
+	return b.ReadCloser.Read(p)
+}
+
+func (b *timeoutBody) close() {
+	b.closed.Store(true)
 }
 
 func healthHandler(c *Context) (interface{}, error) {
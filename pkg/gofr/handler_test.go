@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -15,6 +18,7 @@ import (
 	gofrHTTP "gofr.dev/pkg/gofr/http"
 	"gofr.dev/pkg/gofr/http/response"
 	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
 )
 
 var (
@@ -59,6 +63,145 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestHandler_ServeHTTPTimeout(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		function   Handler
+		statusCode int
+		body       string
+	}{
+		{"handler completes in time", func(*Context) (interface{}, error) {
+			return "done", nil
+		}, http.StatusOK, `{"data":"done"}`},
+		{"deadline of the context", func(c *Context) (interface{}, error) {
+			deadline, ok := c.Deadline()
+
+			return ok && time.Until(deadline) <= 20*time.Millisecond, nil
+		}, http.StatusOK, `{"data":true}`},
+		{"handler ignores the deadline", func(*Context) (interface{}, error) {
+			time.Sleep(100 * time.Millisecond)
+
+			return "done", nil
+		}, http.StatusGatewayTimeout, `{"error":{"message":"request timed out"}}`},
+		{"handler returns the error of the deadline", func(c *Context) (interface{}, error) {
+			<-c.Done()
+
+			return nil, fmt.Errorf("query failed: %w", c.Err())
+		}, http.StatusGatewayTimeout, `{"error":{"message":"request timed out"}}`},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+
+		handler{
+			function:  tc.function,
+			container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+			timeout:   20 * time.Millisecond,
+		}.ServeHTTP(w, r)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, w.Body.String(), tc.body, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestHandler_ServeHTTPTimeoutPanic(t *testing.T) {
+	h := handler{
+		function: func(*Context) (interface{}, error) {
+			panic("handler failed")
+		},
+		container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		timeout:   time.Second,
+	}
+
+	// the panic of the function is raised in the goroutine serving the request.
+	assert.PanicsWithValue(t, "handler failed", func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	})
+}
+
+func TestHandler_ServeHTTPTimeoutStream(t *testing.T) {
+	h := handler{
+		function: func(c *Context) (interface{}, error) {
+			events := make(chan response.Event)
+
+			go func() {
+				defer close(events)
+
+				for i := 1; i <= 3; i++ {
+					time.Sleep(15 * time.Millisecond)
+
+					select {
+					case <-c.StreamContext().Done():
+						return
+					case events <- response.Event{ID: fmt.Sprint(i), Data: "tick"}:
+					}
+				}
+			}()
+
+			return response.SSE{Events: events}, nil
+		},
+		container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		timeout:   20 * time.Millisecond,
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events", http.NoBody))
+
+	// the timeout only bounds the function, not the stream of events it returns.
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "id: 1\ndata: tick\n\nid: 2\ndata: tick\n\nid: 3\ndata: tick\n\n", w.Body.String())
+}
+
+func TestHandler_ServeHTTPTimeoutBody(t *testing.T) {
+	readErr := make(chan error, 1)
+
+	h := handler{
+		function: func(c *Context) (interface{}, error) {
+			time.Sleep(50 * time.Millisecond)
+
+			var v map[string]string
+
+			readErr <- c.Bind(&v)
+
+			return nil, nil
+		},
+		container: &container.Container{Logger: logging.NewLogger(logging.FATAL)},
+		timeout:   20 * time.Millisecond,
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"gofr"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	// the body can not be read once the request timed out.
+	assert.ErrorIs(t, <-readErr, http.ErrBodyReadAfterClose)
+}
+
+func TestHandler_ServeHTTPTimeoutLatePanic(t *testing.T) {
+	logs := testutil.StderrOutputForFunc(func() {
+		h := handler{
+			function: func(*Context) (interface{}, error) {
+				time.Sleep(50 * time.Millisecond)
+
+				panic("handler failed")
+			},
+			container: &container.Container{Logger: logging.NewLogger(logging.ERROR)},
+			timeout:   20 * time.Millisecond,
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	assert.Contains(t, logs, "panic in GET /users after the request timed out: handler failed")
+}
+
 func TestHandler_faviconHandler(t *testing.T) {
 	c := Context{
 		Context: context.Background(),
@@ -116,3 +259,49 @@ func TestHandler_healthHandler(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, h)
 }
+
+func TestHandler_ServeHTTPStreaming(t *testing.T) {
+	var body string
+
+	logs := testutil.StdoutOutputForFunc(func() {
+		a := newTestApp()
+
+		a.GET("/events", func(c *Context) (interface{}, error) {
+			events := make(chan response.Event)
+
+			go func() {
+				defer close(events)
+
+				for i := 1; i <= 3; i++ {
+					select {
+					case <-c.Done():
+						return
+					case events <- response.Event{ID: fmt.Sprint(i), Data: "tick"}:
+					}
+				}
+			}()
+
+			return response.SSE{Events: events}, nil
+		})
+
+		server := httptest.NewServer(a.httpServer.router)
+		defer server.Close()
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/events", http.NoBody)
+
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+
+		defer resp.Body.Close()
+
+		b, _ := io.ReadAll(resp.Body)
+		body = string(b)
+	})
+
+	assert.Equal(t, "id: 1\ndata: tick\n\nid: 2\ndata: tick\n\nid: 3\ndata: tick\n\n", body)
+
+	// the logging middleware records the status of the streamed response.
+	assert.Contains(t, logs, `"uri":"/events","response":200`)
+}
@@ -0,0 +1,44 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+)
+
+// OnStart registers a hook which is run by Run after the container is created and before the servers start
+// listening. Hooks are run in the order of registration. If a hook returns an error, the remaining hooks are
+// skipped and Run returns without starting any server.
+func (a *App) OnStart(hook func(*Context) error) {
+	a.onStartHooks = append(a.onStartHooks, hook)
+}
+
+// OnStop registers a hook which is run during shutdown, after the servers and subscribers have stopped and before
+// the datasources in the container are closed. Hooks are run in the order of registration. All hooks are run even
+// if some of them fail, and their errors are returned by Shutdown.
+func (a *App) OnStop(hook func(*Context) error) {
+	a.onStopHooks = append(a.onStopHooks, hook)
+}
+
+func (a *App) runOnStartHooks(ctx context.Context) error {
+	c := newContext(nil, noopRequest{ctx: ctx}, a.container)
+
+	for _, hook := range a.onStartHooks {
+		if err := hook(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *App) runOnStopHooks(ctx context.Context) error {
+	var err error
+
+	c := newContext(nil, noopRequest{ctx: ctx}, a.container)
+
+	for _, hook := range a.onStopHooks {
+		err = errors.Join(err, hook(c))
+	}
+
+	return err
+}
@@ -0,0 +1,125 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+var errHook = errors.New("hook failed")
+
+func TestApp_OnStartHooksRunInOrder(t *testing.T) {
+	var calls []string
+
+	a := &App{container: &container.Container{Logger: logging.NewLogger(logging.FATAL)}}
+
+	a.OnStart(func(c *Context) error {
+		assert.Equal(t, a.container, c.Container, "TEST Failed.\nhook should get the app container")
+
+		calls = append(calls, "first")
+
+		return nil
+	})
+
+	a.OnStart(func(*Context) error {
+		calls = append(calls, "second")
+
+		return nil
+	})
+
+	err := a.runOnStartHooks(context.Background())
+
+	assert.NoError(t, err, "TEST Failed.\n")
+	assert.Equal(t, []string{"first", "second"}, calls, "TEST Failed.\n")
+}
+
+func TestApp_OnStartHookErrorSkipsRemainingHooks(t *testing.T) {
+	var secondCalled bool
+
+	a := &App{container: &container.Container{Logger: logging.NewLogger(logging.FATAL)}}
+
+	a.OnStart(func(*Context) error {
+		return errHook
+	})
+
+	a.OnStart(func(*Context) error {
+		secondCalled = true
+
+		return nil
+	})
+
+	err := a.runOnStartHooks(context.Background())
+
+	assert.ErrorIs(t, err, errHook, "TEST Failed.\n")
+	assert.False(t, secondCalled, "TEST Failed.\nhooks after a failing hook should not run")
+}
+
+func TestApp_RunWithFailingOnStartHook(t *testing.T) {
+	t.Setenv("HTTP_PORT", "8011")
+	t.Setenv("METRICS_PORT", "2131")
+
+	runReturned := make(chan struct{})
+
+	logs := testutil.StderrOutputForFunc(func() {
+		a := New()
+
+		a.GET("/hello", func(*Context) (interface{}, error) {
+			return helloWorld, nil
+		})
+
+		a.OnStart(func(*Context) error {
+			return errHook
+		})
+
+		go func() {
+			a.Run()
+			close(runReturned)
+		}()
+
+		select {
+		case <-runReturned:
+		case <-time.After(5 * time.Second):
+			t.Error("TEST Failed.\nRun should return when an OnStart hook fails")
+		}
+	})
+
+	assert.Contains(t, logs, "OnStart hook failed", "TEST Failed.\n")
+
+	conn, err := net.DialTimeout("tcp", "localhost:8011", time.Second)
+	if conn != nil {
+		conn.Close()
+	}
+
+	assert.Error(t, err, "TEST Failed.\nserver should not be started when an OnStart hook fails")
+}
+
+func TestApp_OnStopHooksRunOnShutdown(t *testing.T) {
+	var calls []string
+
+	a := &App{container: &container.Container{Logger: logging.NewLogger(logging.FATAL)}}
+
+	a.OnStop(func(*Context) error {
+		calls = append(calls, "first")
+
+		return errHook
+	})
+
+	a.OnStop(func(*Context) error {
+		calls = append(calls, "second")
+
+		return nil
+	})
+
+	err := a.Shutdown(context.Background())
+
+	assert.ErrorIs(t, err, errHook, "TEST Failed.\n")
+	assert.Equal(t, []string{"first", "second"}, calls, "TEST Failed.\nall OnStop hooks should run")
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+var errNotProtoMessage = errors.New("protobuf codec only supports values implementing proto.Message")
+
+// Codec encodes the bodies of the responses and decodes the bodies of the requests of a media type.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// codecRegistry holds the codecs of the media types, in the order of their registration, which is their order of
+// preference when the client accepts any of them.
+type codecRegistry struct {
+	mu         sync.RWMutex
+	codecs     map[string]Codec
+	mediaTypes []string
+}
+
+// codecs are registered for the whole process, like the codecs of the encoding packages.
+var codecs = newCodecRegistry()
+
+func newCodecRegistry() *codecRegistry {
+	r := &codecRegistry{codecs: make(map[string]Codec)}
+
+	r.register(jsonCodec{}, contentTypeJSON)
+	r.register(xmlCodec{}, "application/xml", "text/xml")
+	r.register(yamlCodec{}, "application/yaml", "application/x-yaml", "text/yaml")
+	r.register(msgpackCodec{}, "application/msgpack", "application/x-msgpack", "application/vnd.msgpack")
+	r.register(protobufCodec{}, "application/protobuf", "application/x-protobuf")
+
+	return r
+}
+
+// RegisterCodec registers c as the codec of the given media types, replacing their current codec if any. The codecs are
+// used to encode the responses for the media types accepted by the clients, and to decode the bodies bound to the requests
+// by their Content-Type. JSON, XML, YAML, MessagePack and protobuf are supported by default.
+func RegisterCodec(c Codec, mediaTypes ...string) {
+	codecs.register(c, mediaTypes...)
+}
+
+func (r *codecRegistry) register(c Codec, mediaTypes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, mt := range mediaTypes {
+		mt = strings.ToLower(mt)
+
+		if _, ok := r.codecs[mt]; !ok {
+			r.mediaTypes = append(r.mediaTypes, mt)
+		}
+
+		r.codecs[mt] = c
+	}
+}
+
+func (r *codecRegistry) get(mediaType string) (Codec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.codecs[strings.ToLower(mediaType)]
+
+	return c, ok
+}
+
+func (r *codecRegistry) all() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.mediaTypes...)
+}
+
+// negotiate returns the media type of the response to a request with the given Accept header, from the media types
+// allowed for the route, all the registered ones when empty. It returns false when none of them is accepted.
+func negotiate(accept string, allowed []string) (string, bool) {
+	if len(allowed) == 0 {
+		allowed = codecs.all()
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return allowed[0], true
+	}
+
+	for _, rng := range parseAccept(accept) {
+		for _, mt := range allowed {
+			if matchesMediaRange(rng, mt) {
+				return mt, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// CheckMediaTypes returns an error when the request can not be served by a route restricted to the given media types,
+// which is ErrorUnsupportedMediaType when the body of the request is in another media type and ErrorNotAcceptable when
+// the client accepts none of them. Requests to routes which are not restricted are always served.
+func CheckMediaTypes(r *http.Request, mediaTypes []string) error {
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+
+	if v := r.Header.Get("Content-Type"); v != "" {
+		contentType, _, _ := mime.ParseMediaType(v)
+
+		if !containsMediaType(mediaTypes, contentType) {
+			return ErrorUnsupportedMediaType{MediaType: contentType}
+		}
+	}
+
+	if _, ok := negotiate(r.Header.Get("Accept"), mediaTypes); !ok {
+		return ErrorNotAcceptable{MediaTypes: mediaTypes}
+	}
+
+	return nil
+}
+
+func containsMediaType(mediaTypes []string, mediaType string) bool {
+	for _, mt := range mediaTypes {
+		if strings.EqualFold(mt, mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseAccept returns the media ranges of an Accept header which are accepted, ordered by their preference.
+func parseAccept(accept string) []string {
+	type mediaRange struct {
+		value string
+		q     float64
+	}
+
+	var ranges []mediaRange
+
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		if q > 0 {
+			ranges = append(ranges, mediaRange{value: mt, q: q})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	values := make([]string, len(ranges))
+	for i := range ranges {
+		values[i] = ranges[i].value
+	}
+
+	return values
+}
+
+func matchesMediaRange(rng, mediaType string) bool {
+	switch {
+	case rng == "*/*" || rng == mediaType:
+		return true
+	case strings.HasSuffix(rng, "/*"):
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(rng, "*"))
+	case strings.HasSuffix(rng, "+json"):
+		// structured syntax suffixes, like application/problem+json, are encoded using their base format.
+		return mediaType == contentTypeJSON
+	case rng == "application/problem+xml":
+		// only problem details are encoded in XML this way, as other XML based media types, like application/xhtml+xml,
+		// are not the generic XML the codec writes.
+		return mediaType == "application/xml"
+	}
+
+	return false
+}
+
+// responseContentType returns the Content-Type of a response encoded for mediaType, for which the envelope returned
+// envelopeType. Problem details keep their media type, in the negotiated format.
+func responseContentType(mediaType, envelopeType string) string {
+	if envelopeType != contentTypeProblem {
+		return mediaType
+	}
+
+	switch mediaType {
+	case contentTypeJSON:
+		return contentTypeProblem
+	case "application/xml", "text/xml":
+		return "application/problem+xml"
+	}
+
+	return mediaType
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// xmlCodec encodes values using encoding/xml. Values which it can not encode, like maps and lists, are encoded as
+// elements named after their JSON fields instead.
+type xmlCodec struct{}
+
+func (xmlCodec) Marshal(v interface{}) ([]byte, error) {
+	// encoding/xml encodes the items of lists as sibling root elements, so lists are encoded as a single element.
+	if kind := reflect.Indirect(reflect.ValueOf(v)).Kind(); kind != reflect.Slice && kind != reflect.Array {
+		if data, err := xml.Marshal(v); err == nil {
+			return data, nil
+		}
+	}
+
+	generic, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	enc := xml.NewEncoder(&buf)
+
+	// lists are wrapped in a root element, each of their items being an item element.
+	if list, ok := generic.([]interface{}); ok {
+		generic = map[string]interface{}{"item": list}
+	}
+
+	if err := writeXMLElement(enc, "response", generic); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (xmlCodec) Unmarshal(data []byte, v interface{}) error {
+	return xml.Unmarshal(data, v)
+}
+
+func writeXMLElement(enc *xml.Encoder, name string, v interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if err := writeXMLElement(enc, k, val[k]); err != nil {
+				return err
+			}
+		}
+
+		return enc.EncodeToken(start.End())
+	case []interface{}:
+		// the items of a list are repeated elements with the name of the list.
+		for _, item := range val {
+			if err := writeXMLElement(enc, name, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case nil:
+		return enc.EncodeElement("", start)
+	default:
+		return enc.EncodeElement(fmt.Sprint(val), start)
+	}
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Marshal(v interface{}) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+func (yamlCodec) Unmarshal(data []byte, v interface{}) error {
+	return yaml.Unmarshal(data, v)
+}
+
+// protobufCodec encodes and decodes protobuf messages. The data of the responses is encoded without the
+// envelope, as the envelope is not a protobuf message.
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	if r, ok := v.(response); ok && r.Error == nil {
+		v = r.Data
+	}
+
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, errNotProtoMessage
+	}
+
+	return proto.Marshal(m)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return errNotProtoMessage
+	}
+
+	return proto.Unmarshal(data, m)
+}
+
+// toGeneric converts v to the maps, lists and values it is encoded as in JSON, so that the JSON names of the fields of
+// structs are used by the formats which do not have their own tags. Numbers are kept as json.Number.
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var generic interface{}
+
+	err = dec.Decode(&generic)
+
+	return generic, err
+}
+
+// fromGeneric sets v, which is a pointer, from the maps, lists and values decoded by a format without its own tags,
+// using the JSON names of the fields of structs.
+func fromGeneric(generic, v interface{}) error {
+	data, err := json.Marshal(generic)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
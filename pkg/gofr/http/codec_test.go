@@ -0,0 +1,237 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type codecUser struct {
+	ID   int    `json:"id" xml:"id" yaml:"id"`
+	Name string `json:"name" xml:"name" yaml:"name"`
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		accept    string
+		allowed   []string
+		mediaType string
+		ok        bool
+	}{
+		{"no accept header", "", nil, "application/json", true},
+		{"any media type", "*/*", nil, "application/json", true},
+		{"exact media type", "application/xml", nil, "application/xml", true},
+		{"preferred by quality", "application/json;q=0.5, application/yaml", nil, "application/yaml", true},
+		{"media range", "text/*", nil, "text/xml", true},
+		{"structured syntax suffix", "application/problem+json", nil, "application/json", true},
+		{"problem details in xml", "application/problem+xml", nil, "application/xml", true},
+		{"other xml based media type", "application/xhtml+xml", nil, "", false},
+		{"zero quality is not accepted", "application/xml;q=0", nil, "", false},
+		{"unknown media type", "image/png", nil, "", false},
+		{"restricted route", "*/*", []string{"application/xml"}, "application/xml", true},
+		{"not allowed for route", "application/json", []string{"application/xml"}, "", false},
+	}
+
+	for i, tc := range tests {
+		mediaType, ok := negotiate(tc.accept, tc.allowed)
+
+		assert.Equal(t, tc.mediaType, mediaType, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.ok, ok, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCheckMediaTypes(t *testing.T) {
+	tests := []struct {
+		desc        string
+		contentType string
+		accept      string
+		mediaTypes  []string
+		err         error
+	}{
+		{"route not restricted", "image/png", "image/png", nil, nil},
+		{"supported media types", "application/xml", "application/xml", []string{"application/xml"}, nil},
+		{"no body and any media type", "", "", []string{"application/xml"}, nil},
+		{"unsupported body", "application/json; charset=utf-8", "", []string{"application/xml"},
+			ErrorUnsupportedMediaType{MediaType: "application/json"}},
+		{"not acceptable", "", "application/json", []string{"application/xml"},
+			ErrorNotAcceptable{MediaTypes: []string{"application/xml"}}},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+		r.Header.Set("Content-Type", tc.contentType)
+		r.Header.Set("Accept", tc.accept)
+
+		err := CheckMediaTypes(r, tc.mediaTypes)
+
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	tests := []struct {
+		desc      string
+		mediaType string
+	}{
+		{"json", "application/json"},
+		{"xml", "application/xml"},
+		{"yaml", "application/yaml"},
+		{"msgpack", "application/msgpack"},
+	}
+
+	for i, tc := range tests {
+		c, ok := codecs.get(tc.mediaType)
+		if !ok {
+			t.Fatalf("TEST[%d], Failed.\n%s: codec not registered", i, tc.desc)
+		}
+
+		data, err := c.Marshal(codecUser{ID: 1, Name: "gofr"})
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		var u codecUser
+
+		err = c.Unmarshal(data, &u)
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, codecUser{ID: 1, Name: "gofr"}, u, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMsgpackCodec_Values(t *testing.T) {
+	in := map[string]interface{}{
+		"bool":   true,
+		"nil":    nil,
+		"small":  -5,
+		"int":    -70000,
+		"big":    uint64(1 << 63),
+		"float":  1.5,
+		"string": strings.Repeat("a", 300),
+		"list":   []interface{}{1, "b"},
+	}
+
+	data, err := msgpackCodec{}.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var out map[string]interface{}
+
+	if err := (msgpackCodec{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	expected, _ := json.Marshal(in)
+	actual, _ := json.Marshal(out)
+
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestMsgpackCodec_Truncated(t *testing.T) {
+	data, _ := msgpackCodec{}.Marshal(codecUser{ID: 1, Name: "gofr"})
+
+	var u codecUser
+
+	err := msgpackCodec{}.Unmarshal(data[:len(data)-1], &u)
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestMsgpackCodec_MaxDepth(t *testing.T) {
+	var v interface{}
+
+	nested := append(bytes.Repeat([]byte{0x91}, 100), 0xc0)
+
+	assert.Nil(t, msgpackCodec{}.Unmarshal(nested, &v))
+
+	// the arrays nested past the max depth are refused, instead of exhausting the stack.
+	err := msgpackCodec{}.Unmarshal(bytes.Repeat([]byte{0x91}, 1000000), &v)
+
+	assert.ErrorIs(t, err, errMsgpackTooDeep)
+}
+
+func TestXMLCodec_Generic(t *testing.T) {
+	tests := []struct {
+		desc string
+		data interface{}
+		xml  string
+	}{
+		{"map", map[string]interface{}{"name": "gofr", "id": 1},
+			"<response><id>1</id><name>gofr</name></response>"},
+		{"list", []codecUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+			"<response><item><id>1</id><name>a</name></item><item><id>2</id><name>b</name></item></response>"},
+	}
+
+	for i, tc := range tests {
+		data, err := xmlCodec{}.Marshal(tc.data)
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.xml, string(data), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestProtobufCodec(t *testing.T) {
+	data, err := protobufCodec{}.Marshal(response{Data: wrapperspb.String("gofr")})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	var msg wrapperspb.StringValue
+
+	err = protobufCodec{}.Unmarshal(data, &msg)
+
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(wrapperspb.String("gofr"), &msg))
+
+	_, err = protobufCodec{}.Marshal(codecUser{})
+	assert.ErrorIs(t, err, errNotProtoMessage)
+
+	err = protobufCodec{}.Unmarshal(data, &codecUser{})
+	assert.ErrorIs(t, err, errNotProtoMessage)
+}
+
+type csvCodec struct{}
+
+func (csvCodec) Marshal(v interface{}) ([]byte, error) {
+	u := v.(response).Data.(codecUser)
+
+	return []byte(strings.Join([]string{"1", u.Name}, ",")), nil
+}
+
+func (csvCodec) Unmarshal(data []byte, v interface{}) error {
+	parts := bytes.Split(data, []byte(","))
+	v.(*codecUser).Name = string(parts[1])
+
+	return nil
+}
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec(csvCodec{}, "text/csv")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	r.Header.Set("Accept", "text/csv")
+
+	NewResponder(w, r.Method).WithRequest(r).Respond(codecUser{ID: 1, Name: "gofr"}, nil)
+
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "1,gofr", w.Body.String())
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("1,gofr"))
+	req.Header.Set("Content-Type", "text/csv")
+
+	var u codecUser
+
+	err := NewRequest(req).Bind(&u)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "gofr", u.Name)
+}
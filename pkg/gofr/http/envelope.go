@@ -0,0 +1,98 @@
+package http
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeProblem = "application/problem+json"
+)
+
+// Envelope creates the content type and the body of a response from the data returned by a handler, or from the
+// error it returned when err is not nil. The body is encoded in the media type accepted by the client, unless it is
+// a []byte which is written as it is, so that an Envelope can marshal the body itself. The content type is the one of
+// the body in JSON.
+type Envelope func(r *http.Request, data interface{}, err *ResponseError) (contentType string, body interface{})
+
+// ResponseError is the error returned by a handler along with the status code and the error object it is responded with.
+type ResponseError struct {
+	Err        error
+	StatusCode int
+
+	// Details is the error object of the response, holding the message of the error and the details added by it.
+	Details map[string]interface{}
+}
+
+// DefaultEnvelope wraps the data in the data field of the response and the error object in its error field,
+// e.g. {"data": {"id": 1}} or {"error": {"message": "No entity found with id: 1"}}. The details of the page of
+// paginated data, like response.Paginated, are added in the meta field.
+func DefaultEnvelope(r *http.Request, data interface{}, err *ResponseError) (contentType string, body interface{}) {
+	if err != nil {
+		return contentTypeJSON, response{Data: data, Error: err.Details}
+	}
+
+	data, meta, _, _ := pagination(r, data)
+
+	return contentTypeJSON, response{Data: data, Meta: meta}
+}
+
+// BareEnvelope responds with the data, or with the error object, without wrapping it,
+// e.g. {"id": 1} or {"message": "No entity found with id: 1"}. Paginated data is responded to with the items of the
+// page, the other pages being linked by the Link header only.
+func BareEnvelope(r *http.Request, data interface{}, err *ResponseError) (contentType string, body interface{}) {
+	if err != nil {
+		return contentTypeJSON, err.Details
+	}
+
+	data, _, _, _ = pagination(r, data)
+
+	return contentTypeJSON, data
+}
+
+// ProblemDetailsEnvelope responds to errors with the application/problem+json format of RFC 7807, while the
+// successful responses are created by next, e.g.
+//
+//	{
+//		"type": "about:blank",
+//		"title": "Not Found",
+//		"status": 404,
+//		"detail": "No entity found with id: 1",
+//		"instance": "/users/1",
+//		"traceId": "4bf92f3577b34da6a3ce929d0e0e4736"
+//	}
+//
+// The details added by the error to the error object are added to the problem as extension members.
+func ProblemDetailsEnvelope(next Envelope) Envelope {
+	if next == nil {
+		next = DefaultEnvelope
+	}
+
+	return func(r *http.Request, data interface{}, err *ResponseError) (contentType string, body interface{}) {
+		if err == nil {
+			return next(r, data, nil)
+		}
+
+		problem := make(map[string]interface{})
+
+		for k, v := range err.Details {
+			if k != "message" {
+				problem[k] = v
+			}
+		}
+
+		problem["type"] = "about:blank"
+		problem["title"] = http.StatusText(err.StatusCode)
+		problem["status"] = err.StatusCode
+		problem["detail"] = err.Err.Error()
+		problem["instance"] = r.URL.Path
+
+		if sc := trace.SpanFromContext(r.Context()).SpanContext(); sc.HasTraceID() {
+			problem["traceId"] = sc.TraceID().String()
+		}
+
+		return contentTypeProblem, problem
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	"gofr.dev/pkg/gofr/validation"
+)
+
+type envelopeUser struct {
+	ID int `json:"id" xml:"id"`
+}
+
+func TestResponder_Envelopes(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	validationErr := validation.Error{Fields: []validation.FieldError{{Field: "name", Rule: "required", Reason: "is required"}}}
+
+	testCases := []struct {
+		desc        string
+		envelope    Envelope
+		data        interface{}
+		err         error
+		contentType string
+		body        string
+	}{
+		{"default envelope", nil, envelopeUser{ID: 1}, nil, "application/json", `{"data":{"id":1}}`},
+		{"default envelope error", DefaultEnvelope, nil, ErrorEntityNotFound{Name: "id", Value: "1"}, "application/json",
+			`{"error":{"message":"No entity found with id: 1"}}`},
+		{"bare envelope", BareEnvelope, envelopeUser{ID: 1}, nil, "application/json", `{"id":1}`},
+		{"bare envelope error", BareEnvelope, nil, ErrorEntityNotFound{Name: "id", Value: "1"}, "application/json",
+			`{"message":"No entity found with id: 1"}`},
+		{"problem details with default success", ProblemDetailsEnvelope(nil), envelopeUser{ID: 1}, nil,
+			"application/json", `{"data":{"id":1}}`},
+		{"problem details with bare success", ProblemDetailsEnvelope(BareEnvelope), envelopeUser{ID: 1}, nil,
+			"application/json", `{"id":1}`},
+		{"problem details error", ProblemDetailsEnvelope(BareEnvelope), nil, ErrorEntityNotFound{Name: "id", Value: "1"},
+			"application/problem+json", `{"type":"about:blank","title":"Not Found","status":404,
+				"detail":"No entity found with id: 1","instance":"/users/1","traceId":"4bf92f3577b34da6a3ce929d0e0e4736"}`},
+		{"problem details extension members", ProblemDetailsEnvelope(nil), nil, validationErr,
+			"application/problem+json", `{"type":"about:blank","title":"Bad Request","status":400,
+				"detail":"validation failed: name is required","instance":"/users/1","traceId":"4bf92f3577b34da6a3ce929d0e0e4736",
+				"fields":[{"field":"name","rule":"required","reason":"is required"}]}`},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody).WithContext(ctx)
+
+		NewResponder(w, req.Method).WithRequest(req).WithEnvelope(tc.envelope).Respond(tc.data, tc.err)
+
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_CustomEnvelope(t *testing.T) {
+	xmlEnvelope := func(_ *http.Request, data interface{}, err *ResponseError) (contentType string, body interface{}) {
+		if err != nil {
+			data = err.Details["message"]
+		}
+
+		b, _ := xml.Marshal(data)
+
+		return "application/xml", b
+	}
+
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody)).WithEnvelope(xmlEnvelope).
+		Respond(envelopeUser{ID: 1}, nil)
+
+	assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+	assert.Equal(t, "<envelopeUser><id>1</id></envelopeUser>", w.Body.String())
+
+	// the problem details of a request without a trace do not have a trace id.
+	w = httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody)).WithEnvelope(ProblemDetailsEnvelope(nil)).
+		Respond(nil, ErrorForbidden{})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "traceId")
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gofr.dev/pkg/gofr/internal/params"
+)
+
+// StatusCodeResponder is implemented by the errors which set the status code of the response when they are
+// returned by a handler. Errors which do not implement it are responded to with the status code 500.
+type StatusCodeResponder interface {
+	StatusCode() int
+}
+
+// ResponseMarshaller is implemented by the errors which add details to the error object of the response,
+// besides its message.
+type ResponseMarshaller interface {
+	Response() map[string]interface{}
+}
+
+// ErrorEntityNotFound is used when the entity requested by the client does not exist.
+type ErrorEntityNotFound struct {
+	Name  string
+	Value string
+}
+
+func (e ErrorEntityNotFound) Error() string {
+	return fmt.Sprintf("No entity found with %s: %s", e.Name, e.Value)
+}
+
+func (ErrorEntityNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// ErrorInvalidParam is used when the values of parameters of the request are invalid.
+type ErrorInvalidParam = params.ErrorInvalidParam
+
+// ErrorMissingParam is used when required parameters are missing from the request.
+type ErrorMissingParam = params.ErrorMissingParam
+
+// ErrorUnauthorized is used when the client is not authenticated.
+type ErrorUnauthorized struct {
+	Message string
+}
+
+func (e ErrorUnauthorized) Error() string {
+	return messageOrDefault(e.Message, "Unauthorized")
+}
+
+func (ErrorUnauthorized) StatusCode() int {
+	return http.StatusUnauthorized
+}
+
+// ErrorForbidden is used when the client is not allowed to perform the request.
+type ErrorForbidden struct {
+	Message string
+}
+
+func (e ErrorForbidden) Error() string {
+	return messageOrDefault(e.Message, "Forbidden")
+}
+
+func (ErrorForbidden) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// ErrorConflict is used when the request conflicts with the current state of the entity, like an entity
+// which already exists.
+type ErrorConflict struct {
+	Message string
+}
+
+func (e ErrorConflict) Error() string {
+	return messageOrDefault(e.Message, "Conflict")
+}
+
+func (ErrorConflict) StatusCode() int {
+	return http.StatusConflict
+}
+
+// ErrorUnprocessableEntity is used when the request is well-formed, but its content can not be processed.
+type ErrorUnprocessableEntity struct {
+	Message string
+}
+
+func (e ErrorUnprocessableEntity) Error() string {
+	return messageOrDefault(e.Message, "Unprocessable Entity")
+}
+
+func (ErrorUnprocessableEntity) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ErrorUnsupportedMediaType is used when the body of the request is in a media type which the route does not support.
+type ErrorUnsupportedMediaType struct {
+	MediaType string
+}
+
+func (e ErrorUnsupportedMediaType) Error() string {
+	return fmt.Sprintf("unsupported media type: %s", e.MediaType)
+}
+
+func (ErrorUnsupportedMediaType) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+// ErrorNotAcceptable is used when the client accepts none of the media types the response can be encoded in.
+type ErrorNotAcceptable struct {
+	MediaTypes []string
+}
+
+func (e ErrorNotAcceptable) Error() string {
+	return fmt.Sprintf("the response can only be encoded in: %s", strings.Join(e.MediaTypes, ", "))
+}
+
+func (ErrorNotAcceptable) StatusCode() int {
+	return http.StatusNotAcceptable
+}
+
+// ErrorRequestTimeout is used when the handler of the request does not complete before the timeout of its route.
+// It is responded to with the status code 504, like the errors wrapping context.DeadlineExceeded.
+type ErrorRequestTimeout struct{}
+
+func (ErrorRequestTimeout) Error() string {
+	return "request timed out"
+}
+
+func (ErrorRequestTimeout) StatusCode() int {
+	return http.StatusGatewayTimeout
+}
+
+func messageOrDefault(message, defaultMessage string) string {
+	if message == "" {
+		return defaultMessage
+	}
+
+	return message
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		err        StatusCodeResponder
+		message    string
+		statusCode int
+	}{
+		{"entity not found", ErrorEntityNotFound{Name: "id", Value: "2"}, "No entity found with id: 2", http.StatusNotFound},
+		{"invalid params", ErrorInvalidParam{Params: []string{"age", "email"}}, "'2' invalid parameter(s): age, email",
+			http.StatusBadRequest},
+		{"missing param", ErrorMissingParam{Params: []string{"name"}}, "'1' missing parameter(s): name", http.StatusBadRequest},
+		{"unauthorized", ErrorUnauthorized{}, "Unauthorized", http.StatusUnauthorized},
+		{"forbidden with message", ErrorForbidden{Message: "admins only"}, "admins only", http.StatusForbidden},
+		{"conflict", ErrorConflict{Message: "user already exists"}, "user already exists", http.StatusConflict},
+		{"unprocessable entity", ErrorUnprocessableEntity{}, "Unprocessable Entity", http.StatusUnprocessableEntity},
+		{"unsupported media type", ErrorUnsupportedMediaType{MediaType: "text/csv"}, "unsupported media type: text/csv",
+			http.StatusUnsupportedMediaType},
+		{"not acceptable", ErrorNotAcceptable{MediaTypes: []string{"application/xml", "application/yaml"}},
+			"the response can only be encoded in: application/xml, application/yaml", http.StatusNotAcceptable},
+		{"request timeout", ErrorRequestTimeout{}, "request timed out", http.StatusGatewayTimeout},
+	}
+
+	for i, tc := range testCases {
+		err, ok := tc.err.(error)
+
+		assert.True(t, ok, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.message, err.Error(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.statusCode, tc.err.StatusCode(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// bindValues sets the fields of the struct pointed to by ptr which have the given tag, like `form:"name"`, from the
+// values of their keys. Fields of nested and embedded structs are set as well. It reports whether any field was set,
+// returning ErrorInvalidParam with the keys of the values which could not be parsed into their fields.
+func bindValues(ptr interface{}, tag string, values map[string][]string) (bool, error) {
+	val := reflect.ValueOf(ptr)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return false, nil
+	}
+
+	var invalid []string
+
+	set := bindStruct(val.Elem(), tag, values, &invalid)
+
+	if len(invalid) > 0 {
+		return set, ErrorInvalidParam{Params: invalid}
+	}
+
+	return set, nil
+}
+
+func bindStruct(val reflect.Value, tag string, values map[string][]string, invalid *[]string) bool {
+	var set bool
+
+	t := val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		key := sf.Tag.Get(tag)
+		field := val.Field(i)
+
+		switch {
+		case key == "-":
+			continue
+		case key != "":
+			v, ok := values[key]
+			if !ok || len(v) == 0 {
+				continue
+			}
+
+			if err := setValue(field, v); err != nil {
+				*invalid = append(*invalid, key)
+
+				continue
+			}
+
+			set = true
+		case field.Kind() == reflect.Struct && !isTextField(field):
+			set = bindStruct(field, tag, values, invalid) || set
+		}
+	}
+
+	return set
+}
+
+// setValue parses values into val, which is a string, a number, a bool, a time.Duration, a type implementing
+// encoding.TextUnmarshaler like time.Time, a slice of them or a pointer to any of them.
+func setValue(val reflect.Value, values []string) error {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+
+		return setValue(val.Elem(), values)
+	}
+
+	if val.Kind() == reflect.Slice && !isTextField(val) {
+		slice := reflect.MakeSlice(val.Type(), len(values), len(values))
+
+		for i, v := range values {
+			if err := setValue(slice.Index(i), []string{v}); err != nil {
+				return err
+			}
+		}
+
+		val.Set(slice)
+
+		return nil
+	}
+
+	return setString(val, values[0])
+}
+
+//nolint:exhaustive // only the kinds which can be parsed from a string are supported.
+func setString(val reflect.Value, s string) error {
+	if isTextField(val) {
+		return val.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if val.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		val.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		val.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		val.SetBool(b)
+	default:
+		return errUnsupportedBindType
+	}
+
+	return nil
+}
+
+// isTextField reports whether val is parsed by its own UnmarshalText method, like time.Time.
+func isTextField(val reflect.Value) bool {
+	return val.CanAddr() && reflect.PointerTo(val.Type()).Implements(textUnmarshalerType)
+}
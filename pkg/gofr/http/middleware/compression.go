@@ -0,0 +1,366 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	defaultCompressionMinSize = 1024
+
+	// defaultMaxDecompressedSize is the size of the largest decompressed request bodies, 10 MB.
+	defaultMaxDecompressedSize = 10 << 20
+
+	encodingBrotli  = "br"
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var defaultEncodings = []string{encodingBrotli, encodingGzip, encodingDeflate}
+
+// CompressionConfig configures the Compression middleware.
+type CompressionConfig struct {
+	// MinSize is the size in bytes of the smallest bodies which are compressed, 1024 by default.
+	MinSize int
+
+	// Encodings are the encodings used to compress the responses, among br, gzip and deflate, in the order of
+	// preference of the server for the clients accepting several of them. All of them are used by default.
+	Encodings []string
+
+	// MaxDecompressedSize is the size in bytes of the largest request bodies once decompressed, 10 MB by default.
+	// Reading past it fails with an *http.MaxBytesError, which GoFr responds to with the status code 413, so that a
+	// small compressed body can not expand to gigabytes in memory.
+	MaxDecompressedSize int64
+}
+
+// Compression is a middleware which compresses the bodies of the responses using the encoding accepted by the client
+// in the Accept-Encoding header. The bodies smaller than cfg.MinSize and the ones whose media type is already
+// compressed, like images and archives, are sent as they are. The bodies of the requests compressed using gzip,
+// deflate or br, as set by their Content-Encoding header, are decompressed for the handlers, up to
+// cfg.MaxDecompressedSize. The ETag of the compressed responses is made weak, as their body is not the one it was
+// computed from.
+func Compression(cfg CompressionConfig) func(inner http.Handler) http.Handler {
+	if cfg.MinSize <= 0 {
+		cfg.MinSize = defaultCompressionMinSize
+	}
+
+	if cfg.MaxDecompressedSize <= 0 {
+		cfg.MaxDecompressedSize = defaultMaxDecompressedSize
+	}
+
+	encodings := make([]string, 0, len(defaultEncodings))
+
+	for _, encoding := range cfg.Encodings {
+		if slices.Contains(defaultEncodings, encoding) {
+			encodings = append(encodings, encoding)
+		}
+	}
+
+	if len(encodings) == 0 {
+		encodings = defaultEncodings
+	}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := decompressBody(w, r, cfg.MaxDecompressedSize); err != nil {
+				http.Error(w, "invalid request body encoding", http.StatusBadRequest)
+				return
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), encodings)
+			if encoding == "" || r.Method == http.MethodHead {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: cfg.MinSize}
+
+			// the writer is not closed when the handler panics, leaving the response to the recovery middleware.
+			inner.ServeHTTP(cw, r)
+			cw.Close()
+		})
+	}
+}
+
+// decompressBody replaces the body of r by its decompressed content when it is compressed, limited to maxSize bytes.
+func decompressBody(w http.ResponseWriter, r *http.Request, maxSize int64) error {
+	var (
+		body io.ReadCloser
+		err  error
+	)
+
+	switch strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		return nil
+	case encodingGzip, "x-gzip":
+		body, err = gzip.NewReader(r.Body)
+	case encodingDeflate:
+		body, err = zlib.NewReader(r.Body)
+	case encodingBrotli:
+		body = io.NopCloser(brotli.NewReader(r.Body))
+	default:
+		// the encodings which are not supported are left to the handlers.
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.Body = http.MaxBytesReader(w, body, maxSize)
+	r.ContentLength = -1
+	r.Header.Del("Content-Encoding")
+	r.Header.Del("Content-Length")
+
+	return nil
+}
+
+// negotiateEncoding returns the encoding of the response among the supported ones, following the quality values of
+// the Accept-Encoding header and the order of the supported encodings for equal values. It returns an empty string when
+// the client accepts none of them.
+func negotiateEncoding(header string, supported []string) string {
+	if header == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+
+		q := 1.0
+
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = v
+			}
+		}
+
+		qualities[coding] = q
+	}
+
+	var (
+		best    string
+		bestQ   float64
+		q       float64
+		present bool
+	)
+
+	for _, encoding := range supported {
+		if q, present = qualities[encoding]; !present {
+			q = qualities["*"]
+		}
+
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
+
+// compressible reports whether the bodies of contentType can be made smaller by compressing them.
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case mediaType == "image/svg+xml":
+		return true
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "font/woff"):
+		return false
+	}
+
+	switch mediaType {
+	case "application/zip", "application/gzip", "application/x-gzip", "application/x-bzip2", "application/x-xz",
+		"application/x-7z-compressed", "application/x-rar-compressed", "application/zstd", "application/pdf",
+		"application/octet-stream":
+		return false
+	}
+
+	return true
+}
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressWriter buffers the body of the response until it reaches the minimum size, to decide whether the response
+// is compressed. The status code is held until then as well, as the headers of the response depend on the decision.
+type compressWriter struct {
+	http.ResponseWriter
+
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	started bool
+	encoder encoder
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.started || w.status != 0 {
+		return
+	}
+
+	// informational responses are sent right away, the final response following them.
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.started {
+		return w.write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+
+	if len(w.buf) >= w.minSize {
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+func (w *compressWriter) write(b []byte) (int, error) {
+	if w.encoder != nil {
+		return w.encoder.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// start sends the headers of the response, compressing its body when compress is true and its media type is not
+// already compressed, and writes the buffered body.
+func (w *compressWriter) start(compress bool) error {
+	w.started = true
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	header := w.Header()
+
+	if header.Get("Content-Type") == "" && len(w.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+
+	if compress && w.canCompress() {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+		weakenETag(header)
+
+		w.encoder = newEncoder(w.encoding, w.ResponseWriter)
+	}
+
+	// the responses to conditional requests validate the compressed representation the client already has.
+	if w.status == http.StatusNotModified {
+		weakenETag(header)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.write(w.buf)
+	w.buf = nil
+
+	return err
+}
+
+func (w *compressWriter) canCompress() bool {
+	switch w.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+
+	return w.Header().Get("Content-Encoding") == "" && compressible(w.Header().Get("Content-Type"))
+}
+
+// Flush sends the data written so far to the client, e.g. for streaming responses, compressing it when the media
+// type of the response is not already compressed, whatever its size.
+func (w *compressWriter) Flush() {
+	if !w.started && w.start(true) != nil {
+		return
+	}
+
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, e.g. for WebSockets, when the underlying ResponseWriter supports it.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errHijackNotSupported
+	}
+
+	// the response is not written by the middleware once the connection is taken over.
+	w.started = true
+
+	return hijacker.Hijack()
+}
+
+// Close sends the responses smaller than the minimum size as they are, and completes the compressed ones.
+func (w *compressWriter) Close() {
+	if !w.started {
+		if w.status == 0 && len(w.buf) == 0 {
+			return
+		}
+
+		_ = w.start(false)
+	}
+
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+	}
+}
+
+// weakenETag makes the ETag of the response weak, the strong ones being only valid for the identity body.
+func weakenETag(header http.Header) {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+}
+
+func newEncoder(encoding string, w io.Writer) encoder {
+	switch encoding {
+	case encodingBrotli:
+		return brotli.NewWriter(w)
+	case encodingDeflate:
+		return zlib.NewWriter(w)
+	default:
+		return gzip.NewWriter(w)
+	}
+}
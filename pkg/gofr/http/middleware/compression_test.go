@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+)
+
+func decompress(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+
+	var (
+		r   io.Reader
+		err error
+	)
+
+	switch encoding {
+	case encodingGzip:
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case encodingDeflate:
+		r, err = zlib.NewReader(bytes.NewReader(body))
+	case encodingBrotli:
+		r = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return string(body)
+	}
+
+	if err != nil {
+		t.Fatalf("could not decompress the body: %v", err)
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("could not decompress the body: %v", err)
+	}
+
+	return string(b)
+}
+
+func TestCompression(t *testing.T) {
+	large := strings.Repeat(`{"message":"hello world"}`, 100)
+
+	tests := []struct {
+		desc           string
+		method         string
+		acceptEncoding string
+		contentType    string
+		body           string
+		status         int
+		encoding       string
+	}{
+		{"gzip", http.MethodGet, "gzip", "application/json", large, http.StatusOK, "gzip"},
+		{"deflate", http.MethodGet, "deflate", "application/json", large, http.StatusCreated, "deflate"},
+		{"brotli preferred", http.MethodGet, "gzip, deflate, br", "application/json", large, http.StatusOK, "br"},
+		{"quality values", http.MethodGet, "br;q=0.5, gzip;q=0.8", "application/json", large, http.StatusOK, "gzip"},
+		{"wildcard", http.MethodGet, "*, br;q=0", "text/plain", large, http.StatusOK, "gzip"},
+		{"no accepted encoding", http.MethodGet, "identity", "application/json", large, http.StatusOK, ""},
+		{"no Accept-Encoding", http.MethodGet, "", "application/json", large, http.StatusOK, ""},
+		{"small body", http.MethodGet, "gzip", "application/json", `{"message":"hello"}`, http.StatusOK, ""},
+		{"image", http.MethodGet, "gzip", "image/png", large, http.StatusOK, ""},
+		{"zip", http.MethodGet, "gzip", "application/zip", large, http.StatusOK, ""},
+		{"sniffed content type", http.MethodGet, "gzip", "", large, http.StatusOK, "gzip"},
+		{"HEAD request", http.MethodHead, "gzip", "application/json", "", http.StatusOK, ""},
+		{"no content", http.MethodDelete, "gzip", "", "", http.StatusNoContent, ""},
+	}
+
+	for i, tc := range tests {
+		handler := Compression(CompressionConfig{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if tc.contentType != "" {
+				w.Header().Set("Content-Type", tc.contentType)
+			}
+
+			w.WriteHeader(tc.status)
+
+			// the body is written in several parts, to be buffered until the minimum size.
+			for _, part := range strings.SplitAfter(tc.body, "}") {
+				_, _ = w.Write([]byte(part))
+			}
+		}))
+
+		req := httptest.NewRequest(tc.method, "/hello", http.NoBody)
+		req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.encoding, w.Header().Get("Content-Encoding"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, decompress(t, tc.encoding, w.Body.Bytes()), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCompression_Encodings(t *testing.T) {
+	handler := Compression(CompressionConfig{MinSize: 1, Encodings: []string{"zstd", "gzip"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("hello"))
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", http.NoBody)
+	req.Header.Set("Accept-Encoding", "zstd, br, gzip")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "hello", decompress(t, "gzip", w.Body.Bytes()))
+}
+
+func TestCompression_StatusResponseWriter(t *testing.T) {
+	body := strings.Repeat("hello world ", 200)
+
+	handler := Compression(CompressionConfig{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		_, _ = w.Write([]byte(body[:100]))
+
+		w.(http.Flusher).Flush()
+
+		_, _ = w.Write([]byte(body[100:]))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	// the logging and metrics middlewares wrap the writer of the compression middleware in a StatusResponseWriter.
+	w := httptest.NewRecorder()
+	srw := &StatusResponseWriter{ResponseWriter: w}
+
+	handler.ServeHTTP(srw, req)
+
+	assert.Equal(t, http.StatusOK, srw.status)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, decompress(t, "gzip", w.Body.Bytes()))
+}
+
+func TestCompression_Hijack(t *testing.T) {
+	var err error
+
+	handler := Compression(CompressionConfig{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _, err = w.(http.Hijacker).Hijack()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", http.NoBody)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	handler.ServeHTTP(hijackableRecorder{httptest.NewRecorder()}, req)
+
+	assert.Nil(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.ErrorIs(t, err, errHijackNotSupported)
+}
+
+func TestCompression_RequestBody(t *testing.T) {
+	var gzipped, deflated, bomb bytes.Buffer
+
+	gw := gzip.NewWriter(&gzipped)
+	_, _ = gw.Write([]byte(`{"name":"gofr"}`))
+	_ = gw.Close()
+
+	zw := zlib.NewWriter(&deflated)
+	_, _ = zw.Write([]byte(`{"name":"gofr"}`))
+	_ = zw.Close()
+
+	// a few KB of compressed data expanding to 10 MB, which is only read up to the limit.
+	bw := gzip.NewWriter(&bomb)
+	_, _ = bw.Write(bytes.Repeat([]byte("a"), 10<<20))
+	_ = bw.Close()
+
+	tests := []struct {
+		desc            string
+		contentEncoding string
+		body            []byte
+		status          int
+		received        string
+	}{
+		{"gzip body", "gzip", gzipped.Bytes(), http.StatusOK, `{"name":"gofr"}`},
+		{"deflate body", "deflate", deflated.Bytes(), http.StatusOK, `{"name":"gofr"}`},
+		{"plain body", "", []byte(`{"name":"gofr"}`), http.StatusOK, `{"name":"gofr"}`},
+		{"invalid gzip body", "gzip", []byte(`{"name":"gofr"}`), http.StatusBadRequest, ""},
+		{"body larger than the limit", "gzip", bomb.Bytes(), http.StatusRequestEntityTooLarge, strings.Repeat("a", 100)},
+	}
+
+	for i, tc := range tests {
+		var received string
+
+		handler := Compression(CompressionConfig{MaxDecompressedSize: 100})(http.HandlerFunc(func(w http.ResponseWriter,
+			r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			received = string(b)
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+			}
+
+			assert.Empty(t, r.Header.Get("Content-Encoding"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(tc.body))
+		req.Header.Set("Content-Encoding", tc.contentEncoding)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.received, received, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestCompression_ETag(t *testing.T) {
+	large := strings.Repeat("hello world ", 200)
+
+	tests := []struct {
+		desc           string
+		acceptEncoding string
+		body           string
+		status         int
+		etag           string
+	}{
+		{"compressed response", "gzip", large, http.StatusOK, `W/"v1"`},
+		{"identity response", "", large, http.StatusOK, `"v1"`},
+		{"small response", "gzip", "hello", http.StatusOK, `"v1"`},
+		{"not modified", "gzip", "", http.StatusNotModified, `W/"v1"`},
+	}
+
+	for i, tc := range tests {
+		handler := Compression(CompressionConfig{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(tc.status)
+
+			_, _ = w.Write([]byte(tc.body))
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/hello", http.NoBody)
+		req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.etag, w.Header().Get("ETag"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -1,22 +1,162 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// CORS ia a middleware that adds CORS (Cross-Origin Resource Sharing) headers to the response.
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions}
+	defaultCORSHeaders = []string{"Authorization", "Content-Type", "X-API-KEY"}
+)
+
+// CORSConfig configures the CORS middleware. Its zero value allows all the origins, without credentials.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to make requests, like https://example.com, all of them being allowed
+	// when it is empty or holds *. The subdomains of a domain are allowed using a wildcard, like https://*.example.com.
+	AllowedOrigins []string
+
+	// AllowedMethods are the methods allowed by the preflight requests. When it is empty, the methods of the routes
+	// matching the path of the request are allowed, as returned by RouteMethods.
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers allowed by the preflight requests, Authorization, Content-Type and
+	// X-API-KEY by default.
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers which can be read by the scripts of the allowed origins.
+	ExposedHeaders []string
+
+	// AllowCredentials allows the requests to carry cookies and the Authorization header. It requires AllowedOrigins
+	// to list the allowed origins, credentials not being allowed when all the origins are, as any site could then
+	// make requests with the credentials of its visitors.
+	AllowCredentials bool
+
+	// MaxAge is the time for which the browsers can cache the responses to the preflight requests.
+	MaxAge time.Duration
+
+	// RouteMethods returns the methods of the routes matching the path of r, which are the allowed methods when
+	// AllowedMethods is empty. The preflight requests to the paths without routes are passed to the inner handler.
+	// All the standard methods are allowed when it is nil.
+	RouteMethods func(r *http.Request) []string
+}
+
+// CORS is a middleware that adds CORS (Cross-Origin Resource Sharing) headers to the response, allowing all the origins.
+// Preflight requests are answered by the middleware itself, while other OPTIONS requests reach the handler.
 func CORS() func(inner http.Handler) http.Handler {
+	return CORSWithConfig(CORSConfig{})
+}
+
+// CORSWithConfig is a middleware that adds CORS headers to the responses to the allowed origins, following cfg.
+// Preflight requests from the allowed origins are answered by the middleware itself with the status code 204, while
+// the ones from other origins are rejected with 403.
+func CORSWithConfig(cfg CORSConfig) func(inner http.Handler) http.Handler {
+	if len(cfg.AllowedHeaders) == 0 {
+		cfg.AllowedHeaders = defaultCORSHeaders
+	}
+
+	if cfg.AllowCredentials && cfg.AllowsAnyOrigin() {
+		cfg.AllowCredentials = false
+	}
+
 	return func(inner http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
+			origin, allowed := cfg.allowOrigin(r.Header.Get("Origin"))
+
+			// the responses depend on the origin unless all of them are allowed, whether the origin is allowed or not.
+			if origin != "*" {
+				w.Header().Add("Vary", "Origin")
+			}
+
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+
+				if len(cfg.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
+				}
+			}
+
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				inner.ServeHTTP(w, r)
+				return
+			}
 
-			if r.Method == http.MethodOptions {
-				w.WriteHeader(http.StatusOK)
+			if !allowed {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 				return
 			}
 
-			w.Header().Set("Access-Control-Allow-Headers", "content-type")
+			methods := cfg.methods(r)
+			if len(methods) == 0 {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
 
-			inner.ServeHTTP(w, r)
+			if cfg.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+			}
+
+			w.WriteHeader(http.StatusNoContent)
 		})
 	}
 }
+
+// AllowsAnyOrigin reports whether all the origins are allowed, AllowedOrigins being empty or holding *.
+func (cfg *CORSConfig) AllowsAnyOrigin() bool {
+	return len(cfg.AllowedOrigins) == 0 || slices.Contains(cfg.AllowedOrigins, "*")
+}
+
+// allowOrigin returns the value of the Access-Control-Allow-Origin header for origin, reporting whether it is allowed.
+func (cfg *CORSConfig) allowOrigin(origin string) (string, bool) {
+	if cfg.AllowsAnyOrigin() {
+		return "*", true
+	}
+
+	for _, allowed := range cfg.AllowedOrigins {
+		if origin != "" && matchOrigin(allowed, origin) {
+			return origin, true
+		}
+	}
+
+	return "", false
+}
+
+func (cfg *CORSConfig) methods(r *http.Request) []string {
+	switch {
+	case len(cfg.AllowedMethods) > 0:
+		return cfg.AllowedMethods
+	case cfg.RouteMethods != nil:
+		return cfg.RouteMethods(r)
+	default:
+		return defaultCORSMethods
+	}
+}
+
+// matchOrigin reports whether origin matches the allowed one, whose wildcard matches one or more subdomains.
+func matchOrigin(allowed, origin string) bool {
+	allowed, origin = strings.ToLower(allowed), strings.ToLower(origin)
+
+	prefix, suffix, wildcard := strings.Cut(allowed, "*")
+	if !wildcard {
+		return allowed == origin
+	}
+
+	if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+
+	subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+
+	return !strings.ContainsAny(subdomain, "/:")
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,23 +25,130 @@ func Test_CORS(t *testing.T) {
 
 	tests := []struct {
 		method     string
+		preflight  bool
 		respBody   string
 		respCode   int
 		expHeaders int
 	}{
-		{http.MethodGet, "Sample Response", http.StatusFound, 3},
-		{http.MethodOptions, "", http.StatusOK, 2},
+		{http.MethodGet, false, "Sample Response", http.StatusFound, 1},
+		{http.MethodOptions, true, "", http.StatusNoContent, 3},
+		{http.MethodOptions, false, "Sample Response", http.StatusFound, 1},
 	}
 
 	for i, tc := range tests {
 		req := httptest.NewRequest(tc.method, "/hello", http.NoBody)
+		if tc.preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+		}
+
 		w := httptest.NewRecorder()
 		handler.ServeHTTP(w, req)
 
 		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"), "TEST[%d], Failed.\n", i)
-		assert.Equal(t, "POST, GET, OPTIONS, PUT, DELETE", w.Header().Get("Access-Control-Allow-Methods"), "TEST[%d], Failed.\n", i)
 		assert.Equal(t, tc.expHeaders, len(w.Header()), "TEST[%d], Failed.\n", i)
 		assert.Equal(t, tc.respCode, w.Code, "TEST[%d], Failed.\n", i)
 		assert.Equal(t, tc.respBody, w.Body.String(), "TEST[%d], Failed.\n", i)
 	}
+
+	req := httptest.NewRequest(http.MethodOptions, "/hello", http.NoBody)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization, Content-Type, X-API-KEY", w.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestCORSWithConfig(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"https://example.com", "https://*.gofr.dev"},
+		AllowedHeaders:   []string{"Authorization", "X-Tenant-ID"},
+		ExposedHeaders:   []string{"X-Correlation-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+		RouteMethods: func(r *http.Request) []string {
+			if r.URL.Path == "/users" {
+				return []string{http.MethodGet, http.MethodPost}
+			}
+
+			return nil
+		},
+	}
+
+	handler := CORSWithConfig(cfg)(&MockHandlerForCORS{statusCode: http.StatusOK, response: "Sample Response"})
+
+	tests := []struct {
+		desc        string
+		origin      string
+		path        string
+		preflight   bool
+		statusCode  int
+		allowOrigin string
+		methods     string
+	}{
+		{"allowed origin", "https://example.com", "/users", false, http.StatusOK, "https://example.com", ""},
+		{"subdomain of allowed domain", "https://api.gofr.dev", "/users", false, http.StatusOK, "https://api.gofr.dev", ""},
+		{"nested subdomain", "https://v1.api.gofr.dev", "/users", false, http.StatusOK, "https://v1.api.gofr.dev", ""},
+		{"allowed domain without subdomain", "https://gofr.dev", "/users", false, http.StatusOK, "", ""},
+		{"other scheme", "http://api.gofr.dev", "/users", false, http.StatusOK, "", ""},
+		{"other origin", "https://evil.com", "/users", false, http.StatusOK, "", ""},
+		{"request without origin", "", "/users", false, http.StatusOK, "", ""},
+		{"preflight", "https://example.com", "/users", true, http.StatusNoContent, "https://example.com",
+			"GET, POST"},
+		{"preflight of other origin", "https://evil.com", "/users", true, http.StatusForbidden, "", ""},
+		{"preflight of path without routes", "https://example.com", "/orders", true, http.StatusOK,
+			"https://example.com", ""},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
+		if tc.preflight {
+			req.Method = http.MethodOptions
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.allowOrigin, w.Header().Get("Access-Control-Allow-Origin"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.methods, w.Header().Get("Access-Control-Allow-Methods"), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		// the responses to the other origins vary by origin as well, so that caches do not serve them to allowed ones.
+		assert.Equal(t, "Origin", w.Header().Get("Vary"), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.allowOrigin != "" {
+			assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"), "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, "X-Correlation-ID", w.Header().Get("Access-Control-Expose-Headers"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+
+		if tc.methods != "" {
+			assert.Equal(t, "Authorization, X-Tenant-ID", w.Header().Get("Access-Control-Allow-Headers"), "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestCORSWithConfig_AnyOriginWithCredentials(t *testing.T) {
+	for i, origins := range [][]string{nil, {"*"}, {"https://example.com", "*"}} {
+		handler := CORSWithConfig(CORSConfig{AllowedOrigins: origins, AllowCredentials: true,
+			AllowedMethods: []string{http.MethodGet}})(&MockHandlerForCORS{statusCode: http.StatusOK})
+
+		req := httptest.NewRequest(http.MethodOptions, "/users", http.NoBody)
+		req.Header.Set("Origin", "https://evil.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		// credentials are not allowed along with all the origins, as any site could make requests with them.
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"), "TEST[%d], Failed.\n%v", i, origins)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"), "TEST[%d], Failed.\n%v", i, origins)
+		assert.Equal(t, http.StatusNoContent, w.Code, "TEST[%d], Failed.\n%v", i, origins)
+	}
 }
@@ -0,0 +1,297 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	defaultIdempotencyTTL         = 24 * time.Hour
+	defaultIdempotencyLockTimeout = time.Minute
+	maxIdempotencyKeyLength       = 255
+)
+
+// IdempotencyRecord is the state of a request made with an Idempotency-Key, which is in progress until Completed is
+// set along with its response.
+type IdempotencyRecord struct {
+	// Fingerprint is the hash of the body of the request, which the retries must send as well.
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed,omitempty"`
+
+	StatusCode int         `json:"status_code,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// IdempotencyStore holds the records of the requests made with an Idempotency-Key, shared by the replicas of an app.
+type IdempotencyStore interface {
+	// Reserve creates an in progress record for key which expires after ttl, unless key already has a record, which is
+	// then returned.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, error)
+
+	// Extend makes the in progress record of key with the given fingerprint expire after ttl, while its request is still
+	// being handled. It does nothing when the record is completed or has expired.
+	Extend(ctx context.Context, key, fingerprint string, ttl time.Duration) error
+
+	// Save replaces the record of key by the record of the completed request, which expires after ttl.
+	Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+
+	// Release removes the record of key, so that the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyConfig configures the Idempotency middleware.
+type IdempotencyConfig struct {
+	// Store holds the records of the requests. The middleware does nothing when it is nil.
+	Store IdempotencyStore
+
+	// TTL is the time for which the responses are replayed to the retries, 24 hours by default.
+	TTL time.Duration
+
+	// LockTimeout is the time after which a request still in progress is considered lost, e.g. when the app was
+	// stopped while handling it, so that it can be retried. It is one minute by default, and is extended while the
+	// handler runs, for slower handlers to keep their requests in progress.
+	LockTimeout time.Duration
+
+	// Methods are the methods of the requests for which the Idempotency-Key header is used, POST and PATCH by default.
+	Methods []string
+
+	// Caller identifies the clients, whose keys are not shared with the other clients, so that a client can not be
+	// replayed the response to another one. The clients are identified by the subject of their JWT, their API key or
+	// their IP address following TrustedProxies by default, whichever is found first.
+	Caller func(r *http.Request) string
+
+	// TrustedProxies are the proxies in front of the app, used to find the IP address of the clients by default.
+	TrustedProxies TrustedProxies
+}
+
+// Idempotency is a middleware which makes the requests carrying an Idempotency-Key header safe to retry. The first
+// response to a key is stored and replayed to the retries with the Idempotent-Replayed header, without calling the
+// handler again. While the first request is in progress, the requests with the same key are rejected with the status
+// code 409 Conflict, and the ones reusing the key for a different body with 422 Unprocessable Entity.
+//
+// The keys are scoped by the client, the method, the path and the query of the requests. The responses with a 5xx
+// status code are not stored, so that the request can be retried, and the requests are handled as usual when the
+// store fails. The cookies set by the responses are not stored, as they are not meant for the retries, and neither are
+// the responses flushed to the client while they are written, like streams and Server-Sent Events.
+func Idempotency(cfg IdempotencyConfig) func(http.Handler) http.Handler {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultIdempotencyTTL
+	}
+
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = defaultIdempotencyLockTimeout
+	}
+
+	if len(cfg.Methods) == 0 {
+		cfg.Methods = []string{http.MethodPost, http.MethodPatch}
+	}
+
+	if cfg.Caller == nil {
+		cfg.Caller = cfg.defaultCaller
+	}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get("Idempotency-Key")
+			if idempotencyKey == "" || cfg.Store == nil || !slices.Contains(cfg.Methods, r.Method) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			if len(idempotencyKey) > maxIdempotencyKeyLength {
+				http.Error(w, "invalid Idempotency-Key header", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			sum := sha256.Sum256(body)
+			fingerprint := hex.EncodeToString(sum[:])
+			key := cfg.key(r, idempotencyKey)
+
+			// the record is saved even when the client goes away, as it may retry the request.
+			ctx := context.WithoutCancel(r.Context())
+
+			record, err := cfg.Store.Reserve(ctx, key, fingerprint, cfg.LockTimeout)
+			if err != nil {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			if record != nil {
+				replay(w, record, fingerprint)
+				return
+			}
+
+			rw := &recordingResponseWriter{ResponseWriter: w}
+			saved := false
+
+			// the key is released when the response is not saved, including when the handler panics.
+			defer func() {
+				if !saved {
+					_ = cfg.Store.Release(ctx, key)
+				}
+			}()
+
+			stop := make(chan struct{})
+			defer close(stop)
+
+			go cfg.extendLock(ctx, key, fingerprint, stop)
+
+			inner.ServeHTTP(rw, r)
+
+			if rw.hijacked || rw.flushed || rw.statusCode() >= http.StatusInternalServerError {
+				return
+			}
+
+			header := rw.header
+			header.Del("Set-Cookie")
+
+			saved = cfg.Store.Save(ctx, key, &IdempotencyRecord{
+				Fingerprint: fingerprint,
+				Completed:   true,
+				StatusCode:  rw.statusCode(),
+				Header:      header,
+				Body:        rw.body.Bytes(),
+			}, cfg.TTL) == nil
+		})
+	}
+}
+
+// extendLock extends the in progress record of key every half of LockTimeout until stop is closed, so that it does not
+// expire while the handler runs, while still expiring soon after the app stops.
+func (cfg *IdempotencyConfig) extendLock(ctx context.Context, key, fingerprint string, stop <-chan struct{}) {
+	ticker := time.NewTicker(max(cfg.LockTimeout/2, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_ = cfg.Store.Extend(ctx, key, fingerprint, cfg.LockTimeout)
+		}
+	}
+}
+
+// key returns the key of the record of the request, which is hashed as it holds the identity of the client.
+func (cfg *IdempotencyConfig) key(r *http.Request, idempotencyKey string) string {
+	sum := sha256.Sum256([]byte(cfg.Caller(r) + "\n" + r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n" +
+		idempotencyKey))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (cfg *IdempotencyConfig) defaultCaller(r *http.Request) string {
+	if sub := KeyByJWTSubject(r); sub != "" {
+		return "sub:" + sub
+	}
+
+	if apiKey := KeyByAPIKey(r); apiKey != "" {
+		return "api_key:" + apiKey
+	}
+
+	return "ip:" + cfg.TrustedProxies.ClientIP(r)
+}
+
+// replay writes the response of the record of a request with the same key as the current one, whose body has the
+// given fingerprint.
+func replay(w http.ResponseWriter, record *IdempotencyRecord, fingerprint string) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		http.Error(w, "Idempotency-Key is already used for a different request", http.StatusUnprocessableEntity)
+	case !record.Completed:
+		http.Error(w, "request with the same Idempotency-Key is in progress", http.StatusConflict)
+	default:
+		// the headers set by the outer middlewares for the current request, like its correlation ID, are kept.
+		for name, values := range record.Header {
+			if _, ok := w.Header()[name]; !ok {
+				w.Header()[name] = values
+			}
+		}
+
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(record.StatusCode)
+
+		_, _ = w.Write(record.Body)
+	}
+}
+
+// recordingResponseWriter records the response written through it, to be replayed to the retries of the request.
+// The responses flushed to the client while they are written are not recorded.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+
+	status   int
+	header   http.Header
+	body     bytes.Buffer
+	hijacked bool
+	flushed  bool
+}
+
+func (w *recordingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 && status >= http.StatusOK {
+		w.status = status
+		w.header = w.Header().Clone()
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.flushed {
+		w.body.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingResponseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// Flush sends the data written so far to the client, when the underlying ResponseWriter supports it, in which case the
+// response is not recorded.
+func (w *recordingResponseWriter) Flush() {
+	w.flushed = true
+	w.body.Reset()
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, in which case the response is not recorded.
+func (w *recordingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errHijackNotSupported
+	}
+
+	w.hijacked = true
+
+	return hijacker.Hijack()
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const idempotencyKeyPrefix = "gofr:idempotency:"
+
+// reserveScript sets KEYS[1] to ARGV[1] for ARGV[2] milliseconds unless it is set, in which case its value is returned.
+var reserveScript = redis.NewScript(`
+local record = redis.call('GET', KEYS[1])
+if record then
+	return record
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+
+return false
+`)
+
+// extendScript makes KEYS[1] expire after ARGV[2] milliseconds when it is still set to the in progress record ARGV[1].
+var extendScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+
+return 0
+`)
+
+type redisIdempotencyStore struct {
+	client redis.Cmdable
+}
+
+// NewRedisIdempotencyStore creates an IdempotencyStore holding the records in Redis, for the retries to be handled by
+// any replica of an app. The keys of the records are prefixed with gofr:idempotency:.
+func NewRedisIdempotencyStore(client redis.Cmdable) IdempotencyStore {
+	return &redisIdempotencyStore{client: client}
+}
+
+func (s *redisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string,
+	ttl time.Duration) (*IdempotencyRecord, error) {
+	value, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := reserveScript.Run(ctx, s.client, []string{idempotencyKeyPrefix + key}, value, ttl.Milliseconds()).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var record IdempotencyRecord
+
+	if err := json.Unmarshal([]byte(res), &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (s *redisIdempotencyStore) Extend(ctx context.Context, key, fingerprint string, ttl time.Duration) error {
+	value, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return err
+	}
+
+	return extendScript.Run(ctx, s.client, []string{idempotencyKeyPrefix + key}, value, ttl.Milliseconds()).Err()
+}
+
+func (s *redisIdempotencyStore) Save(ctx context.Context, key string, record *IdempotencyRecord,
+	ttl time.Duration) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, value, ttl).Err()
+}
+
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, idempotencyKeyPrefix+key).Err()
+}
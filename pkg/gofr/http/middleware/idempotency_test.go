@@ -0,0 +1,309 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotencyStore(t *testing.T) (*miniredis.Miniredis, IdempotencyStore) {
+	t.Helper()
+
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("could not start miniredis: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+
+	t.Cleanup(func() {
+		client.Close()
+		s.Close()
+	})
+
+	return s, NewRedisIdempotencyStore(client)
+}
+
+func serveIdempotent(handler http.Handler, method, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/orders", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", key)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestIdempotency(t *testing.T) {
+	_, store := newIdempotencyStore(t)
+
+	calls := 0
+
+	handler := Idempotency(IdempotencyConfig{Store: store})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/orders/1")
+		w.WriteHeader(http.StatusCreated)
+
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+
+	tests := []struct {
+		desc       string
+		method     string
+		key        string
+		body       string
+		statusCode int
+		replayed   string
+		calls      int
+	}{
+		{"first request", http.MethodPost, "key-1", `{"item":"book"}`, http.StatusCreated, "", 1},
+		{"retry", http.MethodPost, "key-1", `{"item":"book"}`, http.StatusCreated, "true", 1},
+		{"different body", http.MethodPost, "key-1", `{"item":"pen"}`, http.StatusUnprocessableEntity, "", 1},
+		{"other key", http.MethodPost, "key-2", `{"item":"book"}`, http.StatusCreated, "", 2},
+		{"same key for another method", http.MethodPatch, "key-1", `{"item":"book"}`, http.StatusCreated, "", 3},
+		{"method without idempotency", http.MethodPut, "key-1", `{"item":"book"}`, http.StatusCreated, "", 4},
+		{"no key", http.MethodPost, "", `{"item":"book"}`, http.StatusCreated, "", 5},
+		{"key too long", http.MethodPost, strings.Repeat("k", 256), `{"item":"book"}`, http.StatusBadRequest, "", 5},
+	}
+
+	for i, tc := range tests {
+		w := serveIdempotent(handler, tc.method, tc.key, tc.body)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.replayed, w.Header().Get("Idempotent-Replayed"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.calls, calls, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		if tc.statusCode == http.StatusCreated {
+			assert.Equal(t, `{"id":1}`, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+			assert.Equal(t, "/orders/1", w.Header().Get("Location"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestIdempotency_Scope(t *testing.T) {
+	_, store := newIdempotencyStore(t)
+
+	calls := 0
+
+	handler := Idempotency(IdempotencyConfig{Store: store})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret"})
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	tests := []struct {
+		desc     string
+		target   string
+		apiKey   string
+		replayed string
+		calls    int
+	}{
+		{"first request", "/orders?dry_run=true", "client-1", "", 1},
+		{"retry", "/orders?dry_run=true", "client-1", "true", 1},
+		{"other query", "/orders", "client-1", "", 2},
+		{"other client", "/orders?dry_run=true", "client-2", "", 3},
+		{"client identified by address", "/orders?dry_run=true", "", "", 4},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodPost, tc.target, strings.NewReader("{}"))
+		req.Header.Set("Idempotency-Key", "key")
+
+		if tc.apiKey != "" {
+			req.Header.Set("X-API-KEY", tc.apiKey)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.replayed, w.Header().Get("Idempotent-Replayed"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.calls, calls, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		// the cookies are only set for the first request, not replayed to the retries.
+		if tc.replayed != "" {
+			assert.Empty(t, w.Header().Get("Set-Cookie"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		} else {
+			assert.Equal(t, "session=secret", w.Header().Get("Set-Cookie"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	_, store := newIdempotencyStore(t)
+
+	started, done := make(chan struct{}), make(chan struct{})
+
+	handler := Idempotency(IdempotencyConfig{Store: store})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-done
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	result := make(chan int)
+
+	go func() {
+		result <- serveIdempotent(handler, http.MethodPost, "key", "{}").Code
+	}()
+
+	<-started
+
+	assert.Equal(t, http.StatusConflict, serveIdempotent(handler, http.MethodPost, "key", "{}").Code)
+
+	close(done)
+
+	assert.Equal(t, http.StatusCreated, <-result)
+	assert.Equal(t, http.StatusCreated, serveIdempotent(handler, http.MethodPost, "key", "{}").Code)
+}
+
+func TestIdempotency_LockExtended(t *testing.T) {
+	s, store := newIdempotencyStore(t)
+
+	started, done := make(chan struct{}), make(chan struct{})
+
+	handler := Idempotency(IdempotencyConfig{Store: store, LockTimeout: 100 * time.Millisecond})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			close(started)
+			<-done
+
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+	result := make(chan int)
+
+	go func() {
+		result <- serveIdempotent(handler, http.MethodPost, "key", "{}").Code
+	}()
+
+	<-started
+
+	key := s.Keys()[0]
+
+	// the lock of the slow handler is extended before it expires.
+	s.FastForward(80 * time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		return s.TTL(key) == 100*time.Millisecond
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, http.StatusConflict, serveIdempotent(handler, http.MethodPost, "key", "{}").Code)
+
+	close(done)
+
+	assert.Equal(t, http.StatusCreated, <-result)
+	assert.Equal(t, defaultIdempotencyTTL, s.TTL(key))
+}
+
+func TestIdempotency_Flushed(t *testing.T) {
+	s, store := newIdempotencyStore(t)
+
+	calls := 0
+
+	handler := Idempotency(IdempotencyConfig{Store: store})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+
+		_, _ = w.Write([]byte("data: 2\n\n"))
+	}))
+
+	for i := 0; i < 2; i++ {
+		w := serveIdempotent(handler, http.MethodPost, "key", "{}")
+
+		assert.Equal(t, "data: 1\n\ndata: 2\n\n", w.Body.String(), "TEST[%d], Failed.\n", i)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"), "TEST[%d], Failed.\n", i)
+	}
+
+	// the streamed responses are not stored, so every request is handled.
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, s.Keys())
+}
+
+func TestIdempotency_NotStored(t *testing.T) {
+	s, store := newIdempotencyStore(t)
+
+	status := http.StatusServiceUnavailable
+
+	handler := Idempotency(IdempotencyConfig{Store: store})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if status == 0 {
+			panic("handler failed")
+		}
+
+		w.WriteHeader(status)
+	}))
+
+	// the failed requests can be retried.
+	assert.Equal(t, http.StatusServiceUnavailable, serveIdempotent(handler, http.MethodPost, "key", "{}").Code)
+	assert.Empty(t, s.Keys())
+
+	status = 0
+
+	assert.Panics(t, func() { serveIdempotent(handler, http.MethodPost, "key", "{}") })
+	assert.Empty(t, s.Keys())
+
+	status = http.StatusOK
+
+	w := serveIdempotent(handler, http.MethodPost, "key", "{}")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+
+	// the requests are handled as usual when Redis is down.
+	s.Close()
+
+	assert.Equal(t, http.StatusOK, serveIdempotent(handler, http.MethodPost, "key", "{}").Code)
+}
+
+func TestRedisIdempotencyStore(t *testing.T) {
+	s, store := newIdempotencyStore(t)
+	ctx := context.Background()
+
+	record, err := store.Reserve(ctx, "key", "fingerprint", time.Minute)
+
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+	assert.Equal(t, time.Minute, s.TTL(idempotencyKeyPrefix+"key"))
+
+	record, err = store.Reserve(ctx, "key", "other", time.Minute)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &IdempotencyRecord{Fingerprint: "fingerprint"}, record)
+
+	saved := &IdempotencyRecord{Fingerprint: "fingerprint", Completed: true, StatusCode: http.StatusCreated,
+		Header: http.Header{"Location": {"/orders/1"}}, Body: []byte(`{"id":1}`)}
+
+	s.FastForward(30 * time.Second)
+
+	assert.Nil(t, store.Extend(ctx, "key", "fingerprint", time.Minute))
+	assert.Equal(t, time.Minute, s.TTL(idempotencyKeyPrefix+"key"))
+
+	assert.Nil(t, store.Save(ctx, "key", saved, time.Hour))
+	assert.Equal(t, time.Hour, s.TTL(idempotencyKeyPrefix+"key"))
+
+	// the completed records are not extended.
+	assert.Nil(t, store.Extend(ctx, "key", "fingerprint", time.Minute))
+	assert.Equal(t, time.Hour, s.TTL(idempotencyKeyPrefix+"key"))
+
+	record, err = store.Reserve(ctx, "key", "fingerprint", time.Minute)
+
+	assert.Nil(t, err)
+	assert.Equal(t, saved, record)
+
+	assert.Nil(t, store.Release(ctx, "key"))
+	assert.False(t, s.Exists(idempotencyKeyPrefix+"key"))
+}
@@ -1,17 +1,20 @@
 package middleware
 
 import (
-	"encoding/json"
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
-	"runtime/debug"
 	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
 )
 
+var errHijackNotSupported = errors.New("response writer does not support hijacking the connection")
+
 // StatusResponseWriter Defines own Response Writer to be used for logging of status - as http.ResponseWriter does not let us read status.
 type StatusResponseWriter struct {
 	http.ResponseWriter
@@ -23,6 +26,43 @@ func (w *StatusResponseWriter) WriteHeader(status int) {
 	w.ResponseWriter.WriteHeader(status)
 }
 
+func (w *StatusResponseWriter) Write(b []byte) (int, error) {
+	// the status is implicitly OK when the body is written without calling WriteHeader.
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the data written so far to the client, e.g. for streaming responses, when the underlying
+// ResponseWriter supports it.
+func (w *StatusResponseWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, e.g. for WebSockets, when the underlying ResponseWriter supports it.
+// The response is then recorded with the 101 Switching Protocols status.
+func (w *StatusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errHijackNotSupported
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
+
 // RequestLog represents a log entry for HTTP requests.
 type RequestLog struct {
 	TraceID      string `json:"trace_id,omitempty"`
@@ -92,8 +132,6 @@ func Logging(logger logger) func(inner http.Handler) http.Handler {
 				}
 			}(srw, r)
 
-			defer panicRecovery(srw, logger)
-
 			inner.ServeHTTP(srw, r)
 		})
 	}
@@ -112,33 +150,3 @@ func getIPAddress(r *http.Request) string {
 
 	return strings.TrimSpace(ipAddress)
 }
-
-type panicLog struct {
-	Error      string `json:"error,omitempty"`
-	StackTrace string `json:"stack_trace,omitempty"`
-}
-
-func panicRecovery(w http.ResponseWriter, logger logger) {
-	re := recover()
-
-	if re != nil {
-		var e string
-		switch t := re.(type) {
-		case string:
-			e = t
-		case error:
-			e = t.Error()
-		default:
-			e = "Unknown panic type"
-		}
-		logger.Error(panicLog{
-			Error:      e,
-			StackTrace: string(debug.Stack()),
-		})
-
-		w.WriteHeader(http.StatusInternalServerError)
-
-		res := map[string]interface{}{"code": http.StatusInternalServerError, "status": "ERROR", "message": "Some unexpected error has occurred"}
-		_ = json.NewEncoder(w).Encode(res)
-	}
-}
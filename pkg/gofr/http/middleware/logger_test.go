@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -60,63 +62,6 @@ func testHandler(w http.ResponseWriter, _ *http.Request) {
 	_, _ = w.Write([]byte("Test Handler"))
 }
 
-func Test_LoggingMiddlewareStringPanicHandling(t *testing.T) {
-	logs := testutil.StderrOutputForFunc(func() {
-		req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://dummy", http.NoBody)
-
-		rr := httptest.NewRecorder()
-
-		handler := Logging(testutil.NewMockLogger(testutil.DEBUGLOG))(http.HandlerFunc(testStringPanicHandler))
-
-		handler.ServeHTTP(rr, req)
-	})
-
-	assert.Contains(t, logs, "gofr.dev/pkg/gofr/http/middleware.testStringPanicHandler")
-}
-
-// Test handler that uses the middleware.
-func testStringPanicHandler(_ http.ResponseWriter, r *http.Request) {
-	panic(r.URL.Path)
-}
-
-func Test_LoggingMiddlewareErrorPanicHandling(t *testing.T) {
-	logs := testutil.StderrOutputForFunc(func() {
-		req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://dummy", http.NoBody)
-
-		rr := httptest.NewRecorder()
-
-		handler := Logging(testutil.NewMockLogger(testutil.DEBUGLOG))(http.HandlerFunc(testErrorPanicHandler))
-
-		handler.ServeHTTP(rr, req)
-	})
-
-	assert.Contains(t, logs, "gofr.dev/pkg/gofr/http/middleware.testErrorPanicHandler")
-}
-
-// Test handler that uses the middleware.
-func testErrorPanicHandler(http.ResponseWriter, *http.Request) {
-	panic(testutil.CustomError{ErrorMessage: "panic"})
-}
-
-func Test_LoggingMiddlewareUnknownPanicHandling(t *testing.T) {
-	logs := testutil.StderrOutputForFunc(func() {
-		req, _ := http.NewRequestWithContext(context.Background(), "GET", "http://dummy", http.NoBody)
-
-		rr := httptest.NewRecorder()
-
-		handler := Logging(testutil.NewMockLogger(testutil.DEBUGLOG))(http.HandlerFunc(testUnknownPanicHandler))
-
-		handler.ServeHTTP(rr, req)
-	})
-
-	assert.Contains(t, logs, "gofr.dev/pkg/gofr/http/middleware.testUnknownPanicHandler")
-}
-
-// Test handler that uses the middleware.
-func testUnknownPanicHandler(w http.ResponseWriter, _ *http.Request) {
-	panic(w)
-}
-
 func TestRequestLog_PrettyPrint(t *testing.T) {
 	rl := &RequestLog{
 		TraceID:      "7e5c0e9a58839071d4d006dd1d0f4f3a",
@@ -156,3 +101,43 @@ func Test_ColorForStatusCode(t *testing.T) {
 		assert.Equal(t, tc.expOut, out)
 	}
 }
+
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func TestStatusResponseWriter_Hijack(t *testing.T) {
+	srw := &StatusResponseWriter{ResponseWriter: hijackableRecorder{httptest.NewRecorder()}}
+
+	_, _, err := srw.Hijack()
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, srw.status)
+
+	srw = &StatusResponseWriter{ResponseWriter: httptest.NewRecorder()}
+
+	_, _, err = srw.Hijack()
+
+	assert.ErrorIs(t, err, errHijackNotSupported)
+	assert.Equal(t, 0, srw.status)
+}
+
+func TestStatusResponseWriter_ImplicitStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	srw := &StatusResponseWriter{ResponseWriter: rec}
+
+	srw.Flush()
+
+	assert.Equal(t, http.StatusOK, srw.status)
+	assert.True(t, rec.Flushed)
+
+	srw = &StatusResponseWriter{ResponseWriter: httptest.NewRecorder()}
+
+	_, _ = srw.Write([]byte("data"))
+
+	assert.Equal(t, http.StatusOK, srw.status)
+}
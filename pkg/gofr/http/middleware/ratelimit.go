@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+// RateLimit is the number of requests allowed to a client in a period, e.g. 100 requests per minute. It is enforced
+// using a token bucket, so that a client can make up to Requests requests at once, the tokens being refilled evenly
+// over Period.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitResult is the state of the bucket of a client after a request.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+
+	// RetryAfter is the time until the next request is allowed, when the request is not allowed.
+	RetryAfter time.Duration
+
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+}
+
+// RateLimitStore holds the buckets of the clients, in memory for a single instance or in Redis for the limits to
+// hold across the replicas of an app.
+type RateLimitStore interface {
+	// Take takes a token from the bucket of key for a request, creating a full bucket when it does not exist.
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitKeyFunc returns the key of the client making the request, whose requests share a bucket.
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimitConfig configures the RateLimiter middleware.
+type RateLimitConfig struct {
+	// Limit applies to all the routes which do not have a limit in Routes, when its Requests is not 0. The periods of
+	// the limits must be at least a millisecond.
+	Limit RateLimit
+
+	// Routes holds the limits of routes, by the method and the path template of the route like "POST /orders", or by
+	// the path template only for all its methods. The requests to a route with its own limit use their own buckets.
+	Routes map[string]RateLimit
+
+	// Key identifies the clients, by their IP address following TrustedProxies when it is nil. The requests for which
+	// Key returns an empty key are identified by their IP address as well.
+	Key RateLimitKeyFunc
+
+	// TrustedProxies are the proxies in front of the app, whose X-Forwarded-For header is used to find the IP address
+	// of the clients. The header is ignored when there are none, as it is set by the clients otherwise.
+	TrustedProxies TrustedProxies
+
+	// Store holds the buckets of the clients, in memory when it is nil.
+	Store RateLimitStore
+}
+
+// TrustedProxies identifies the proxies in front of the app, either by their number or by their IP addresses. The IP
+// address of a client is then the right-most address of the X-Forwarded-For header which is not one of a trusted proxy,
+// the addresses on its left being set by the client.
+type TrustedProxies struct {
+	// Count is the number of proxies in front of the app, the last of which is the peer of the connection.
+	Count int
+
+	// CIDRs are the networks of the proxies in front of the app.
+	CIDRs []*net.IPNet
+}
+
+// ClientIP returns the IP address of the client making the request, which is the address of the peer of the connection
+// when there are no trusted proxies.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	ip := KeyByIP(r)
+
+	if p.Count <= 0 && len(p.CIDRs) == 0 {
+		return ip
+	}
+
+	var hops []string
+
+	for _, hop := range strings.Split(r.Header.Get("X-Forwarded-For"), ",") {
+		if hop = strings.TrimSpace(hop); hop != "" {
+			hops = append(hops, hop)
+		}
+	}
+
+	hops = append(hops, ip)
+
+	for i := len(hops) - 1; i > 0; i-- {
+		if len(hops)-1-i >= p.Count && !p.trusted(hops[i]) {
+			return hops[i]
+		}
+	}
+
+	// all the hops are trusted, the left-most one being the client.
+	return hops[0]
+}
+
+func (p TrustedProxies) trusted(ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, n := range p.CIDRs {
+		if n.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KeyByIP identifies the clients by the IP address of the peer of the connection. Behind proxies, the clients are
+// identified using TrustedProxies.ClientIP instead, which is the default key of RateLimiter.
+func KeyByIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
+
+// KeyByAPIKey identifies the clients by the API key of the X-API-KEY header.
+func KeyByAPIKey(r *http.Request) string {
+	return r.Header.Get("X-API-KEY")
+}
+
+// KeyByJWTSubject identifies the clients by the subject of their JWT, which is available when OAuth is enabled by a
+// middleware running before the rate limiter, like the one of the App. It is empty otherwise.
+func KeyByJWTSubject(r *http.Request) string {
+	claims, ok := r.Context().Value(JWTClaim("JWTClaims")).(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	sub, _ := claims["sub"].(string)
+
+	return sub
+}
+
+// KeyByRoute makes all the clients of a route share its bucket, limiting the total rate of requests to the route.
+func KeyByRoute(r *http.Request) string {
+	return r.Method + " " + routeTemplate(r)
+}
+
+// RateLimiter is a middleware which rejects the requests exceeding the rate limit of their client with the status
+// code 429 Too Many Requests and a Retry-After header. The RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+// headers are set on all the limited responses. The rejections are counted by the app_http_rate_limited_total
+// metric. Requests are allowed when the store fails, so that the app stays available when Redis is down.
+func RateLimiter(cfg RateLimitConfig, metrics metrics) func(http.Handler) http.Handler {
+	if cfg.Key == nil {
+		cfg.Key = cfg.TrustedProxies.ClientIP
+	}
+
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryRateLimitStore()
+	}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isWellKnown(r.URL.Path) {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			limit, scope, ok := cfg.limitOf(r)
+			if !ok {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			key := cfg.Key(r)
+			if key == "" {
+				key = cfg.TrustedProxies.ClientIP(r)
+			}
+
+			res, err := cfg.Store.Take(r.Context(), scope+key, limit)
+			if err != nil {
+				inner.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+
+			if !res.Allowed {
+				if metrics != nil {
+					metrics.IncrementCounter(r.Context(), "app_http_rate_limited_total",
+						"path", routeTemplate(r), "method", r.Method)
+				}
+
+				w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(res.RetryAfter), 1)))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+
+				return
+			}
+
+			inner.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limitOf returns the limit of the route of r along with the scope of its buckets, reporting whether it is limited.
+func (cfg *RateLimitConfig) limitOf(r *http.Request) (limit RateLimit, scope string, ok bool) {
+	template := routeTemplate(r)
+
+	for _, route := range []string{r.Method + " " + template, template} {
+		if limit, ok := cfg.Routes[route]; ok && limit.valid() {
+			return limit, route + "|", true
+		}
+	}
+
+	return cfg.Limit, "", cfg.Limit.valid()
+}
+
+func (l RateLimit) valid() bool {
+	return l.Requests > 0 && l.Period >= time.Millisecond
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return r.URL.Path
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
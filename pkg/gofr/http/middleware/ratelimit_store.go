@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// sweepInterval is the interval at which the memory store removes the buckets which are full, as they are the same
+// as the buckets created for new clients.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration
+}
+
+type memoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore creates a RateLimitStore holding the buckets in memory, for the limits of a single instance.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (s *memoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests), updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens := refill(b.tokens, now.Sub(b.updated), limit)
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	b.tokens, b.updated, b.period = tokens, now, limit.Period
+
+	return newRateLimitResult(allowed, tokens, limit), nil
+}
+
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// tokenBucketScript takes a token from the bucket in KEYS[1] for a limit of ARGV[1] requests per ARGV[2] microseconds,
+// using the time of Redis so that the clocks of the replicas do not matter. It returns whether the request is allowed
+// along with the tokens left, as a string since Redis truncates the numbers returned by scripts.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or capacity
+local updated = tonumber(state[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(now - updated, 0) * capacity / period)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', string.format('%.0f', now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(period / 1000))
+
+return {allowed, tostring(tokens)}
+`)
+
+type redisRateLimitStore struct {
+	client redis.Scripter
+}
+
+// NewRedisRateLimitStore creates a RateLimitStore holding the buckets in Redis, for the limits to hold across the
+// replicas of an app. The keys of the buckets are prefixed with gofr:ratelimit: and expire once the buckets are full.
+func NewRedisRateLimitStore(client redis.Scripter) RateLimitStore {
+	return &redisRateLimitStore{client: client}
+}
+
+func (s *redisRateLimitStore) Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	res, err := tokenBucketScript.Run(ctx, s.client, []string{"gofr:ratelimit:" + key},
+		limit.Requests, limit.Period.Microseconds()).Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	allowed, _ := res[0].(int64)
+	value, _ := res[1].(string)
+
+	tokens, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	return newRateLimitResult(allowed == 1, tokens, limit), nil
+}
+
+// refill returns the tokens of a bucket after elapsed, which are refilled evenly over the period of the limit.
+func refill(tokens float64, elapsed time.Duration, limit RateLimit) float64 {
+	capacity := float64(limit.Requests)
+
+	return min(capacity, tokens+float64(elapsed)*capacity/float64(limit.Period))
+}
+
+func newRateLimitResult(allowed bool, tokens float64, limit RateLimit) RateLimitResult {
+	// the time taken to refill a token.
+	perToken := float64(limit.Period) / float64(limit.Requests)
+
+	res := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(limit.Requests) - tokens) * perToken),
+	}
+
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) * perToken)
+	}
+
+	return res
+}
@@ -0,0 +1,241 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errStore = errors.New("store is down")
+
+func newRateLimitedRouter(cfg RateLimitConfig, metrics metrics) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(RateLimiter(cfg, metrics))
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	router.Handle("/orders", ok).Methods(http.MethodGet, http.MethodPost)
+	router.Handle("/users/{id}", ok)
+	router.Handle("/.well-known/health", ok)
+
+	return router
+}
+
+func serveRateLimited(router http.Handler, method, target, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, http.NoBody)
+	req.RemoteAddr = ip + ":1234"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestRateLimiter(t *testing.T) {
+	metrics := &mockMetrics{}
+	metrics.On("IncrementCounter", mock.Anything, "app_http_rate_limited_total",
+		[]string{"path", "/users/{id}", "method", http.MethodGet}).Return(nil)
+
+	router := newRateLimitedRouter(RateLimitConfig{Limit: RateLimit{Requests: 2, Period: time.Minute}}, metrics)
+
+	w := serveRateLimited(router, http.MethodGet, "/users/1", "10.0.0.1")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+
+	// the limit is shared by all the routes.
+	w = serveRateLimited(router, http.MethodGet, "/orders", "10.0.0.1")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = serveRateLimited(router, http.MethodGet, "/users/2", "10.0.0.1")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	metrics.AssertNumberOfCalls(t, "IncrementCounter", 1)
+
+	// other clients have their own buckets, and the well-known routes are not limited.
+	assert.Equal(t, http.StatusOK, serveRateLimited(router, http.MethodGet, "/users/1", "10.0.0.2").Code)
+	assert.Equal(t, http.StatusOK, serveRateLimited(router, http.MethodGet, "/.well-known/health", "10.0.0.1").Code)
+}
+
+func TestRateLimiter_Routes(t *testing.T) {
+	router := newRateLimitedRouter(RateLimitConfig{
+		Routes: map[string]RateLimit{
+			"POST /orders": {Requests: 1, Period: time.Second},
+			"/users/{id}":  {Requests: 2, Period: time.Second},
+		},
+	}, nil)
+
+	tests := []struct {
+		desc       string
+		method     string
+		target     string
+		statusCode int
+	}{
+		{"first order", http.MethodPost, "/orders", http.StatusOK},
+		{"second order", http.MethodPost, "/orders", http.StatusTooManyRequests},
+		{"route without limit", http.MethodGet, "/orders", http.StatusOK},
+		{"first user", http.MethodGet, "/users/1", http.StatusOK},
+		{"second user", http.MethodDelete, "/users/2", http.StatusOK},
+		{"third user", http.MethodGet, "/users/3", http.StatusTooManyRequests},
+	}
+
+	for i, tc := range tests {
+		w := serveRateLimited(router, tc.method, tc.target, "10.0.0.1")
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, RateLimit) (RateLimitResult, error) {
+	return RateLimitResult{}, errStore
+}
+
+func TestRateLimiter_StoreError(t *testing.T) {
+	router := newRateLimitedRouter(RateLimitConfig{Limit: RateLimit{Requests: 1, Period: time.Second},
+		Store: failingStore{}}, nil)
+
+	w := serveRateLimited(router, http.MethodGet, "/orders", "10.0.0.1")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-API-KEY", "key")
+	req = req.WithContext(context.WithValue(req.Context(), JWTClaim("JWTClaims"), jwt.MapClaims{"sub": "user-1"}))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+	assert.Equal(t, "10.0.0.1", KeyByIP(req))
+	assert.Equal(t, "key", KeyByAPIKey(req))
+	assert.Equal(t, "user-1", KeyByJWTSubject(req))
+	assert.Equal(t, "GET /users/1", KeyByRoute(req))
+
+	// the header set by the client is ignored.
+	req.Header.Set("X-Forwarded-For", "192.168.0.1")
+	assert.Equal(t, "10.0.0.1", KeyByIP(req))
+
+	assert.Equal(t, "", KeyByJWTSubject(httptest.NewRequest(http.MethodGet, "/", http.NoBody)))
+}
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		desc         string
+		forwardedFor string
+		proxies      TrustedProxies
+		expectedIP   string
+	}{
+		{"no trusted proxies", "1.1.1.1", TrustedProxies{}, "10.0.0.1"},
+		{"one proxy", "6.6.6.6, 1.1.1.1", TrustedProxies{Count: 1}, "1.1.1.1"},
+		{"two proxies", "6.6.6.6, 1.1.1.1, 10.0.0.2", TrustedProxies{Count: 2}, "1.1.1.1"},
+		{"fewer hops than proxies", "1.1.1.1", TrustedProxies{Count: 3}, "1.1.1.1"},
+		{"proxy networks", "6.6.6.6, 1.1.1.1, 10.0.0.3, 10.0.0.2", TrustedProxies{CIDRs: []*net.IPNet{proxies}}, "1.1.1.1"},
+		{"spoofed proxy address", "10.0.0.9, 1.1.1.1", TrustedProxies{CIDRs: []*net.IPNet{proxies}}, "1.1.1.1"},
+		{"no header", "", TrustedProxies{Count: 1}, "10.0.0.1"},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		if tc.forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+		}
+
+		assert.Equal(t, tc.expectedIP, tc.proxies.ClientIP(req), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryRateLimitStore{buckets: make(map[string]*bucket), now: func() time.Time { return now }}
+	limit := RateLimit{Requests: 2, Period: 2 * time.Second}
+
+	tests := []struct {
+		desc    string
+		elapsed time.Duration
+		result  RateLimitResult
+	}{
+		{"full bucket", 0, RateLimitResult{Allowed: true, Remaining: 1, Reset: time.Second}},
+		{"last token", 0, RateLimitResult{Allowed: true, Remaining: 0, Reset: 2 * time.Second}},
+		{"empty bucket", 500 * time.Millisecond,
+			RateLimitResult{Remaining: 0, RetryAfter: 500 * time.Millisecond, Reset: 1500 * time.Millisecond}},
+		{"refilled token", 500 * time.Millisecond, RateLimitResult{Allowed: true, Remaining: 0, Reset: 2 * time.Second}},
+	}
+
+	for i, tc := range tests {
+		now = now.Add(tc.elapsed)
+
+		res, err := store.Take(context.Background(), "client", limit)
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.result, res, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	// the buckets which are full again are removed.
+	now = now.Add(sweepInterval)
+
+	_, _ = store.Take(context.Background(), "other", limit)
+
+	assert.Len(t, store.buckets, 1)
+}
+
+func TestRedisRateLimitStore(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("could not start miniredis: %v", err)
+	}
+
+	defer s.Close()
+
+	// the buckets are refilled using the time of Redis.
+	s.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	store := NewRedisRateLimitStore(client)
+	limit := RateLimit{Requests: 2, Period: time.Minute}
+
+	res, err := store.Take(context.Background(), "client", limit)
+
+	assert.Nil(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+
+	_, _ = store.Take(context.Background(), "client", limit)
+
+	res, err = store.Take(context.Background(), "client", limit)
+
+	assert.Nil(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 30*time.Second, res.RetryAfter)
+	assert.True(t, s.Exists("gofr:ratelimit:client"))
+
+	s.Close()
+
+	_, err = store.Take(context.Background(), "client", limit)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type panicLog struct {
+	TraceID    string `json:"trace_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+	StackTrace string `json:"stack_trace,omitempty"`
+}
+
+// Recovery is a middleware which recovers from the panics of the inner handlers, so that a panic only fails the request
+// in which it happens. The panic is logged along with its stack trace and the trace ID of the request, counted by the
+// app_http_panic_total metric and recorded on the span of the request. The response is then written by respond, or is
+// a JSON error with the status code 500 when respond is nil. Nothing is written when the response was already started.
+func Recovery(logger logger, metrics metrics, respond func(w http.ResponseWriter, r *http.Request)) func(http.Handler) http.Handler {
+	if respond == nil {
+		respond = respondPanic
+	}
+
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srw := &StatusResponseWriter{ResponseWriter: w}
+
+			defer func() {
+				re := recover()
+				if re == nil {
+					return
+				}
+
+				// the handlers abort the responses by panicking with http.ErrAbortHandler, which is left to the server.
+				if err, ok := re.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(re)
+				}
+
+				span := trace.SpanFromContext(r.Context())
+				message := fmt.Sprint(re)
+
+				if logger != nil {
+					logger.Error(panicLog{
+						TraceID:    span.SpanContext().TraceID().String(),
+						Error:      message,
+						StackTrace: string(debug.Stack()),
+					})
+				}
+
+				if metrics != nil {
+					metrics.IncrementCounter(r.Context(), "app_http_panic_total", "path", routeTemplate(r), "method", r.Method)
+				}
+
+				span.SetStatus(codes.Error, message)
+
+				if srw.status == 0 {
+					respond(srw, r)
+				}
+			}()
+
+			inner.ServeHTTP(srw, r)
+		})
+	}
+}
+
+func respondPanic(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"message": "some unexpected error has occurred"},
+	})
+}
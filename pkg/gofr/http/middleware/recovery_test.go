@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestRecovery(t *testing.T) {
+	tests := []struct {
+		desc    string
+		handler http.HandlerFunc
+		message string
+	}{
+		{"string panic", testStringPanicHandler, "/users/1"},
+		{"error panic", testErrorPanicHandler, "panic"},
+		{"unknown panic", testUnknownPanicHandler, "&{"},
+	}
+
+	for i, tc := range tests {
+		metrics := &mockMetrics{}
+		metrics.On("IncrementCounter", mock.Anything, "app_http_panic_total",
+			[]string{"path", "/users/{id}", "method", http.MethodGet}).Return(nil)
+
+		w := httptest.NewRecorder()
+
+		logs := testutil.StderrOutputForFunc(func() {
+			router := mux.NewRouter()
+			router.Use(Recovery(testutil.NewMockLogger(testutil.DEBUGLOG), metrics, nil))
+			router.Handle("/users/{id}", tc.handler)
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", http.NoBody))
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, `{"error":{"message":"some unexpected error has occurred"}}`+"\n", w.Body.String(),
+			"TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, logs, tc.message, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, logs, "gofr.dev/pkg/gofr/http/middleware.test", "TEST[%d], Failed.\n%s", i, tc.desc)
+		metrics.AssertNumberOfCalls(t, "IncrementCounter", 1)
+	}
+}
+
+func TestRecovery_Respond(t *testing.T) {
+	respond := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	handler := Recovery(nil, nil, respond)(http.HandlerFunc(testStringPanicHandler))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestRecovery_ResponseStarted(t *testing.T) {
+	handler := Recovery(nil, nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+
+		panic("failed while writing the response")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestRecovery_AbortHandler(t *testing.T) {
+	handler := Recovery(nil, nil, nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	// the aborted responses are left to the server, which closes the connection.
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	})
+}
+
+func testStringPanicHandler(_ http.ResponseWriter, r *http.Request) {
+	panic(r.URL.Path)
+}
+
+func testErrorPanicHandler(http.ResponseWriter, *http.Request) {
+	panic(testutil.CustomError{ErrorMessage: "panic"})
+}
+
+func testUnknownPanicHandler(w http.ResponseWriter, _ *http.Request) {
+	panic(w)
+}
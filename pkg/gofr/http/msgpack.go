@@ -0,0 +1,94 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+var errMsgpackTooDeep = errors.New("msgpack: exceeded max depth")
+
+// msgpackMaxDepth is the max nesting of the arrays and maps decoded, the same as the one of encoding/json, so that
+// deeply nested data can not exhaust the stack of the decoder, which recurses for each level.
+const msgpackMaxDepth = 10000
+
+// msgpackCodec encodes and decodes MessagePack. Structs are encoded as maps keyed by the JSON names of their fields,
+// the same way they are encoded as JSON.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	if err := checkMsgpackDepth(data); err != nil {
+		return err
+	}
+
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+
+	return dec.Decode(v)
+}
+
+// checkMsgpackDepth walks the arrays and maps of data without recursion, returning errMsgpackTooDeep when they are
+// nested deeper than msgpackMaxDepth.
+func checkMsgpackDepth(data []byte) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+
+	// remaining holds the number of values left in each of the arrays and maps being walked.
+	remaining := []int{1}
+
+	for len(remaining) > 0 {
+		last := len(remaining) - 1
+		if remaining[last] == 0 {
+			remaining = remaining[:last]
+
+			continue
+		}
+
+		remaining[last]--
+
+		c, err := dec.PeekCode()
+		if err != nil {
+			return err
+		}
+
+		var n int
+
+		switch {
+		case msgpcode.IsFixedArray(c) || c == msgpcode.Array16 || c == msgpcode.Array32:
+			n, err = dec.DecodeArrayLen()
+		case msgpcode.IsFixedMap(c) || c == msgpcode.Map16 || c == msgpcode.Map32:
+			n, err = dec.DecodeMapLen()
+			n *= 2
+		default:
+			err = dec.Skip()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			if len(remaining) > msgpackMaxDepth {
+				return errMsgpackTooDeep
+			}
+
+			remaining = append(remaining, n)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	resTypes "gofr.dev/pkg/gofr/http/response"
+)
+
+// pageMeta is the meta of the responses of lists paginated with the page or offset query parameters.
+type pageMeta struct {
+	Page   int    `json:"page,omitempty" xml:"page,omitempty" yaml:"page,omitempty"`
+	Limit  int    `json:"limit" xml:"limit" yaml:"limit"`
+	Offset int    `json:"offset" xml:"offset" yaml:"offset"`
+	Total  int    `json:"total" xml:"total" yaml:"total"`
+	Next   string `json:"next,omitempty" xml:"next,omitempty" yaml:"next,omitempty"`
+	Prev   string `json:"prev,omitempty" xml:"prev,omitempty" yaml:"prev,omitempty"`
+}
+
+// cursorMeta is the meta of the responses of lists paginated with cursors.
+type cursorMeta struct {
+	Limit      int    `json:"limit" xml:"limit" yaml:"limit"`
+	NextCursor string `json:"nextCursor,omitempty" xml:"nextCursor,omitempty" yaml:"nextCursor,omitempty"`
+	Next       string `json:"next,omitempty" xml:"next,omitempty" yaml:"next,omitempty"`
+}
+
+// link is a link of the Link header of RFC 8288, like the link to the next page.
+type link struct {
+	rel string
+	url string
+}
+
+// pagination returns the items of a paginated response to r along with its meta and the links to the other pages,
+// reporting whether data is paginated.
+func pagination(r *http.Request, data interface{}) (items, meta interface{}, links []link, ok bool) {
+	switch v := data.(type) {
+	case resTypes.Paginated:
+		m, links := offsetPagination(r, v)
+
+		return v.Data, m, links, true
+	case resTypes.CursorPaginated:
+		m := cursorMeta{Limit: v.Limit, NextCursor: v.NextCursor}
+
+		if v.NextCursor != "" {
+			m.Next = pageURL(r, map[string]string{"cursor": v.NextCursor, "limit": strconv.Itoa(v.Limit)})
+			links = append(links, link{rel: "next", url: m.Next})
+		}
+
+		return v.Data, m, links, true
+	}
+
+	return data, nil, nil, false
+}
+
+func offsetPagination(r *http.Request, p resTypes.Paginated) (pageMeta, []link) {
+	m := pageMeta{Page: p.Page, Limit: p.Limit, Offset: p.Offset, Total: p.Total}
+
+	if p.Limit <= 0 {
+		return m, nil
+	}
+
+	// the links use the parameters the client requested the page with.
+	at := func(offset int) string {
+		if p.Page > 0 {
+			return pageURL(r, map[string]string{"page": strconv.Itoa(offset/p.Limit + 1), "limit": strconv.Itoa(p.Limit)})
+		}
+
+		return pageURL(r, map[string]string{"offset": strconv.Itoa(offset), "limit": strconv.Itoa(p.Limit)})
+	}
+
+	lastOffset := 0
+	if p.Total > 0 {
+		lastOffset = (p.Total - 1) / p.Limit * p.Limit
+	}
+
+	links := []link{{rel: "first", url: at(0)}}
+
+	if p.Offset > 0 {
+		m.Prev = at(max(p.Offset-p.Limit, 0))
+		links = append(links, link{rel: "prev", url: m.Prev})
+	}
+
+	// the offset is compared without adding the limit to it, as it can be as large as the client wants.
+	if p.Offset < p.Total-p.Limit {
+		m.Next = at(p.Offset + p.Limit)
+		links = append(links, link{rel: "next", url: m.Next})
+	}
+
+	return m, append(links, link{rel: "last", url: at(lastOffset)})
+}
+
+// pageURL returns the URL of the request with the given query parameters replaced, relative to its host.
+func pageURL(r *http.Request, params map[string]string) string {
+	query := r.URL.Query()
+	for k, v := range params {
+		query.Set(k, v)
+	}
+
+	u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+
+	return u.String()
+}
+
+// linkHeader returns the value of the Link header of RFC 8288 for links.
+func linkHeader(links []link) string {
+	values := make([]string, len(links))
+	for i, l := range links {
+		values[i] = fmt.Sprintf("<%s>; rel=%q", l.url, l.rel)
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	resTypes "gofr.dev/pkg/gofr/http/response"
+)
+
+func TestResponder_RespondPaginated(t *testing.T) {
+	tests := []struct {
+		desc   string
+		target string
+		data   interface{}
+		body   string
+		link   string
+	}{
+		{"page", "/users?page=2&limit=10&sort=name",
+			resTypes.Paginated{Data: []int{1, 2}, Page: 2, Limit: 10, Offset: 10, Total: 35},
+			`{"data":[1,2],"meta":{"page":2,"limit":10,"offset":10,"total":35,` +
+				`"next":"/users?limit=10&page=3&sort=name","prev":"/users?limit=10&page=1&sort=name"}}`,
+			`</users?limit=10&page=1&sort=name>; rel="first", </users?limit=10&page=1&sort=name>; rel="prev", ` +
+				`</users?limit=10&page=3&sort=name>; rel="next", </users?limit=10&page=4&sort=name>; rel="last"`},
+		{"first offset page", "/users",
+			resTypes.Paginated{Data: []int{1}, Limit: 20, Total: 21},
+			`{"data":[1],"meta":{"limit":20,"offset":0,"total":21,"next":"/users?limit=20&offset=20"}}`,
+			`</users?limit=20&offset=0>; rel="first", </users?limit=20&offset=20>; rel="next", ` +
+				`</users?limit=20&offset=20>; rel="last"`},
+		{"empty list", "/users", resTypes.Paginated{Limit: 20},
+			`{"meta":{"limit":20,"offset":0,"total":0}}`,
+			`</users?limit=20&offset=0>; rel="first", </users?limit=20&offset=0>; rel="last"`},
+		{"offset past the end", "/users?offset=9223372036854775807",
+			resTypes.Paginated{Limit: 20, Offset: math.MaxInt, Total: 5},
+			`{"meta":{"limit":20,"offset":9223372036854775807,"total":5,"prev":"/users?limit=20&offset=9223372036854775787"}}`,
+			`</users?limit=20&offset=0>; rel="first", </users?limit=20&offset=9223372036854775787>; rel="prev", ` +
+				`</users?limit=20&offset=0>; rel="last"`},
+		{"cursor", "/users?cursor=a", resTypes.CursorPaginated{Data: []int{1}, Limit: 1, NextCursor: "b"},
+			`{"data":[1],"meta":{"limit":1,"nextCursor":"b","next":"/users?cursor=b&limit=1"}}`,
+			`</users?cursor=b&limit=1>; rel="next"`},
+		{"last cursor page", "/users?cursor=b", resTypes.CursorPaginated{Data: []int{2}, Limit: 1},
+			`{"data":[2],"meta":{"limit":1}}`, ""},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)).Respond(tc.data, nil)
+
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.link, w.Header().Get("Link"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestBareEnvelope_Paginated(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/users", http.NoBody)).WithEnvelope(BareEnvelope).
+		Respond(resTypes.Paginated{Data: []int{1}, Limit: 1, Total: 2}, nil)
+
+	assert.JSONEq(t, `[1]`, w.Body.String())
+	assert.Contains(t, w.Header().Get("Link"), `</users?limit=1&offset=1>; rel="next"`)
+}
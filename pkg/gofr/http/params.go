@@ -0,0 +1,29 @@
+package http
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr/internal/params"
+)
+
+// ParamInt parses the value of the parameter key as an int. It returns ErrorMissingParam when the value is empty and
+// ErrorInvalidParam when it is not an int, so that the requests of all the transports parse their parameters the same way.
+func ParamInt(key, value string) (int, error) {
+	return params.Int(key, value)
+}
+
+// ParamFloat parses the value of the parameter key as a float64, returning the same errors as ParamInt.
+func ParamFloat(key, value string) (float64, error) {
+	return params.Float(key, value)
+}
+
+// ParamBool parses the value of the parameter key as a bool, which is one of the values accepted by strconv.ParseBool,
+// returning the same errors as ParamInt.
+func ParamBool(key, value string) (bool, error) {
+	return params.Bool(key, value)
+}
+
+// ParamTime parses the value of the parameter key as a time in the RFC 3339 format, returning the same errors as ParamInt.
+func ParamTime(key, value string) (time.Time, error) {
+	return params.Time(key, value)
+}
@@ -3,13 +3,14 @@ package http
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -19,8 +20,9 @@ const (
 )
 
 var (
-	errNoFileFound    = errors.New("no files were bounded")
-	errNonPointerBind = errors.New("bind error, cannot bind to a non pointer type")
+	errNoFileFound         = errors.New("no files were bounded")
+	errNonPointerBind      = errors.New("bind error, cannot bind to a non pointer type")
+	errUnsupportedBindType = errors.New("bind error, unsupported field type")
 )
 
 // Request is an abstraction over the underlying http.Request. This abstraction is useful because it allows us
@@ -43,6 +45,48 @@ func (r *Request) Param(key string) string {
 	return r.req.URL.Query().Get(key)
 }
 
+// Params returns all the values of the query parameter with the given key, like the values of ?id=1&id=2.
+func (r *Request) Params(key string) []string {
+	return r.req.URL.Query()[key]
+}
+
+// ParamInt returns the query parameter with the given key as an int. It returns ErrorMissingParam when the
+// parameter is not set and ErrorInvalidParam when it is not an int, which are responded to with the status code 400.
+func (r *Request) ParamInt(key string) (int, error) {
+	return ParamInt(key, r.Param(key))
+}
+
+// ParamFloat returns the query parameter with the given key as a float64, with the same errors as ParamInt.
+func (r *Request) ParamFloat(key string) (float64, error) {
+	return ParamFloat(key, r.Param(key))
+}
+
+// ParamBool returns the query parameter with the given key as a bool, with the same errors as ParamInt.
+func (r *Request) ParamBool(key string) (bool, error) {
+	return ParamBool(key, r.Param(key))
+}
+
+// ParamTime returns the query parameter with the given key as a time in the RFC 3339 format, with the same errors
+// as ParamInt.
+func (r *Request) ParamTime(key string) (time.Time, error) {
+	return ParamTime(key, r.Param(key))
+}
+
+// Header returns the first value of the header with the given key.
+func (r *Request) Header(key string) string {
+	return r.req.Header.Get(key)
+}
+
+// Cookie returns the value of the cookie with the given name, or an empty string when the request does not have it.
+func (r *Request) Cookie(name string) string {
+	c, err := r.req.Cookie(name)
+	if err != nil {
+		return ""
+	}
+
+	return c.Value
+}
+
 // Context returns the context of the request.
 func (r *Request) Context() context.Context {
 	return r.req.Context()
@@ -53,24 +97,65 @@ func (r *Request) PathParam(key string) string {
 	return r.pathParams[key]
 }
 
-// Bind parses the request body and binds it to the provided interface.
+// Bind parses the request body and binds it to the provided interface. The body is decoded by the Codec registered
+// for its Content-Type, while forms bind their values to the fields with `form` tags and multipart forms bind their
+// files as well. The query and path parameters are then bound to the fields with `query` and `path` tags, so that a
+// struct can hold all the inputs of a request, e.g.
+//
+//	type UpdateUser struct {
+//		ID    int      `path:"id"`
+//		Force bool     `query:"force"`
+//		Name  string   `json:"name" form:"name"`
+//		Tags  []string `json:"tags" form:"tags"`
+//	}
+//
+// Values which can not be parsed into their fields are reported by ErrorInvalidParam.
 func (r *Request) Bind(i interface{}) error {
 	v := r.req.Header.Get("content-type")
-	contentType := strings.Split(v, ";")[0]
+	contentType := strings.TrimSpace(strings.Split(v, ";")[0])
 
-	switch contentType {
-	case "application/json":
-		body, err := r.body()
-		if err != nil {
-			return err
-		}
+	if err := r.bindBody(contentType, i); err != nil {
+		return err
+	}
+
+	return r.bindParams(i)
+}
 
-		return json.Unmarshal(body, &i)
+func (r *Request) bindBody(contentType string, i interface{}) error {
+	switch contentType {
 	case "multipart/form-data":
 		return r.bindMultipart(i)
+	case "application/x-www-form-urlencoded":
+		return r.bindForm(i)
 	}
 
-	return nil
+	c, ok := codecs.get(contentType)
+	if !ok {
+		return nil
+	}
+
+	body, err := r.body()
+	if err != nil {
+		return err
+	}
+
+	return c.Unmarshal(body, i)
+}
+
+// bindParams binds the query and path parameters of the request to the fields with `query` and `path` tags.
+func (r *Request) bindParams(i interface{}) error {
+	if _, err := bindValues(i, "query", r.req.URL.Query()); err != nil {
+		return err
+	}
+
+	pathParams := make(map[string][]string, len(r.pathParams))
+	for k, v := range r.pathParams {
+		pathParams[k] = []string{v}
+	}
+
+	_, err := bindValues(i, "path", pathParams)
+
+	return err
 }
 
 // HostName retrieves the hostname from the request.
@@ -83,6 +168,16 @@ func (r *Request) HostName() string {
 	return fmt.Sprintf("%s://%s", proto, r.req.Host)
 }
 
+// ClientCertificate returns the certificate verified during the mutual TLS handshake of the request.
+// It returns nil when the request was not made over mutual TLS.
+func (r *Request) ClientCertificate() *x509.Certificate {
+	if r.req.TLS == nil || len(r.req.TLS.VerifiedChains) == 0 || len(r.req.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return r.req.TLS.VerifiedChains[0][0]
+}
+
 func (r *Request) body() ([]byte, error) {
 	bodyBytes, err := io.ReadAll(r.req.Body)
 	if err != nil {
@@ -108,14 +203,33 @@ func (r *Request) bindMultipart(ptr any) error {
 
 	fd := formData{files: r.req.MultipartForm.File}
 
-	ok, err := fd.mapStruct(ptrVal, &reflect.StructField{})
+	filesSet, err := fd.mapStruct(ptrVal, &reflect.StructField{})
 	if err != nil {
 		return err
 	}
 
-	if !ok {
+	valuesSet, err := bindValues(ptr, "form", r.req.MultipartForm.Value)
+	if err != nil {
+		return err
+	}
+
+	if !filesSet && !valuesSet {
 		return errNoFileFound
 	}
 
 	return nil
 }
+
+func (r *Request) bindForm(ptr any) error {
+	if reflect.ValueOf(ptr).Kind() != reflect.Ptr {
+		return errNonPointerBind
+	}
+
+	if err := r.req.ParseForm(); err != nil {
+		return err
+	}
+
+	_, err := bindValues(ptr, "form", r.req.PostForm)
+
+	return err
+}
@@ -3,14 +3,20 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"gofr.dev/pkg/gofr/file"
@@ -23,6 +29,34 @@ func TestParam(t *testing.T) {
 	}
 }
 
+func TestRequest_Accessors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/abc?id=1&id=2&limit=10&active=yes&since=2023-01-02T15:04:05Z", http.NoBody)
+	r.Header.Set("X-Request-Id", "abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "xyz"})
+
+	req := NewRequest(r)
+
+	assert.Equal(t, []string{"1", "2"}, req.Params("id"))
+	assert.Nil(t, req.Params("name"))
+	assert.Equal(t, "abc", req.Header("X-Request-Id"))
+	assert.Equal(t, "xyz", req.Cookie("session"))
+	assert.Equal(t, "", req.Cookie("token"))
+
+	limit, err := req.ParamInt("limit")
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+
+	since, err := req.ParamTime("since")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), since)
+
+	_, err = req.ParamBool("active")
+	assert.Equal(t, ErrorInvalidParam{Params: []string{"active"}}, err)
+
+	_, err = req.ParamFloat("ratio")
+	assert.Equal(t, ErrorMissingParam{Params: []string{"ratio"}}, err)
+}
+
 func TestBind(t *testing.T) {
 	r := httptest.NewRequest("POST", "/abc", strings.NewReader(`{"a": "b", "b": 5}`))
 	r.Header.Set("content-type", "application/json")
@@ -112,6 +146,135 @@ func TestBind_FileSuccess(t *testing.T) {
 	assert.Nil(t, x.FileNotPresent)
 }
 
+func TestBind_MediaTypes(t *testing.T) {
+	msgpackBody, _ := msgpackCodec{}.Marshal(map[string]interface{}{"a": "b", "b": 5})
+
+	tests := []struct {
+		desc        string
+		contentType string
+		body        []byte
+	}{
+		{"json with charset", "application/json; charset=utf-8", []byte(`{"a": "b", "b": 5}`)},
+		{"xml", "application/xml", []byte(`<x><a>b</a><b>5</b></x>`)},
+		{"yaml", "application/yaml", []byte("a: b\nb: 5\n")},
+		{"msgpack", "application/msgpack", msgpackBody},
+	}
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/abc", bytes.NewReader(tc.body))
+		r.Header.Set("Content-Type", tc.contentType)
+
+		x := struct {
+			A string `json:"a" xml:"a" yaml:"a"`
+			B int    `json:"b" xml:"b" yaml:"b"`
+		}{}
+
+		err := NewRequest(r).Bind(&x)
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, "b", x.A, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, 5, x.B, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+type bindUser struct {
+	ID       int           `path:"id"`
+	Force    bool          `query:"force"`
+	Name     string        `form:"name"`
+	Age      *int          `form:"age"`
+	Score    float64       `form:"score"`
+	Tags     []string      `form:"tags"`
+	Born     time.Time     `form:"born"`
+	Timeout  time.Duration `form:"timeout"`
+	Ignored  string        `form:"-"`
+	bindMeta               // embedded structs are bound as well
+}
+
+type bindMeta struct {
+	Source string `form:"source"`
+}
+
+func TestBind_Form(t *testing.T) {
+	form := url.Values{
+		"name": {"gofr"}, "age": {"3"}, "score": {"9.5"}, "tags": {"go", "web"}, "born": {"2023-01-02T15:04:05Z"},
+		"timeout": {"2s"}, "Ignored": {"x"}, "source": {"form"},
+	}
+
+	var multipartBody bytes.Buffer
+
+	writer := multipart.NewWriter(&multipartBody)
+
+	for k, values := range form {
+		for _, v := range values {
+			_ = writer.WriteField(k, v)
+		}
+	}
+
+	writer.Close()
+
+	tests := []struct {
+		desc        string
+		contentType string
+		body        io.Reader
+	}{
+		{"url-encoded form", "application/x-www-form-urlencoded", strings.NewReader(form.Encode())},
+		{"multipart form", writer.FormDataContentType(), &multipartBody},
+	}
+
+	age := 3
+
+	for i, tc := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/users/7?force=true", tc.body)
+		r.Header.Set("Content-Type", tc.contentType)
+		r = mux.SetURLVars(r, map[string]string{"id": "7"})
+
+		var u bindUser
+
+		err := NewRequest(r).Bind(&u)
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, bindUser{ID: 7, Force: true, Name: "gofr", Age: &age, Score: 9.5, Tags: []string{"go", "web"},
+			Born: time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), Timeout: 2 * time.Second, bindMeta: bindMeta{Source: "form"}},
+			u, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestBind_ParamsWithJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/users/7?force=1", strings.NewReader(`{"name": "gofr"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r = mux.SetURLVars(r, map[string]string{"id": "7"})
+
+	var u struct {
+		ID    int    `path:"id"`
+		Force bool   `query:"force"`
+		Name  string `json:"name"`
+	}
+
+	err := NewRequest(r).Bind(&u)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 7, u.ID)
+	assert.True(t, u.Force)
+	assert.Equal(t, "gofr", u.Name)
+}
+
+func TestBind_InvalidParams(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/users?force=maybe", strings.NewReader("age=old&score=1"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var u bindUser
+
+	err := NewRequest(r).Bind(&u)
+
+	assert.Equal(t, ErrorInvalidParam{Params: []string{"age"}}, err)
+
+	r = httptest.NewRequest(http.MethodGet, "/users?force=maybe", http.NoBody)
+
+	err = NewRequest(r).Bind(&u)
+
+	assert.Equal(t, ErrorInvalidParam{Params: []string{"force"}}, err)
+}
+
 func TestBind_NoContentType(t *testing.T) {
 	req := NewRequest(httptest.NewRequest("POST", "/abc", strings.NewReader(`{"a": "b", "b": 5}`)))
 	x := struct {
@@ -207,3 +370,24 @@ func Test_bindMultipart_Fail_ParseMultiPart(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "http: multipart handled by MultipartReader", err.Error())
 }
+
+func TestRequest_ClientCertificate(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing-service"}}
+
+	testCases := []struct {
+		desc     string
+		state    *tls.ConnectionState
+		expected *x509.Certificate
+	}{
+		{"request without TLS", nil, nil},
+		{"TLS request without client certificate", &tls.ConnectionState{}, nil},
+		{"mutual TLS request", &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}, cert},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/abc", http.NoBody)
+		r.TLS = tc.state
+
+		assert.Equal(t, tc.expected, NewRequest(r).ClientCertificate(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -1,80 +1,261 @@
 package http
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 
 	resTypes "gofr.dev/pkg/gofr/http/response"
 )
 
-// NewResponder creates a new Responder instance from the given http.ResponseWriter..
+// NewResponder creates a new Responder instance from the given http.ResponseWriter and the method of the request.
+// The request being responded to is set with WithRequest, for the responses to be negotiated with its Accept header,
+// paginated from its URL and streamed until it is done.
 func NewResponder(w http.ResponseWriter, method string) *Responder {
-	return &Responder{w: w, method: method}
+	return &Responder{w: w, req: &http.Request{Method: method, URL: &url.URL{}, Header: make(http.Header)}, method: method}
 }
 
 // Responder encapsulates an http.ResponseWriter and is responsible for crafting structured responses.
 type Responder struct {
 	w      http.ResponseWriter
+	req    *http.Request
 	method string
+
+	// envelope creates the body of the responses, DefaultEnvelope being used when it is nil.
+	envelope Envelope
+
+	// mediaTypes are the media types the response can be encoded in, all the ones with a registered Codec when empty.
+	mediaTypes []string
+}
+
+// WithRequest sets the request being responded to, along with its method.
+func (r *Responder) WithRequest(req *http.Request) *Responder {
+	r.req = req
+	r.method = req.Method
+
+	return r
+}
+
+// WithEnvelope sets the Envelope creating the body of the responses.
+func (r *Responder) WithEnvelope(e Envelope) *Responder {
+	r.envelope = e
+
+	return r
+}
+
+// WithMediaTypes restricts the media types the responses can be encoded in.
+func (r *Responder) WithMediaTypes(mediaTypes []string) *Responder {
+	r.mediaTypes = mediaTypes
+
+	return r
 }
 
 // Respond sends a response with the given data and handles potential errors, setting appropriate
 // status codes and formatting responses as JSON or raw data as needed.
 func (r Responder) Respond(data interface{}, err error) {
-	statusCode, errorObj := r.HTTPStatusFromError(err)
+	statusCode, errorObj := r.errorDetails(data, err)
+
+	switch v := data.(type) {
+	case resTypes.Response:
+		// the headers and cookies are set even for errors, while the status code is the one of the error.
+		r.setHeaders(v.Headers, v.Cookies)
+
+		if err == nil && v.StatusCode != 0 {
+			statusCode = v.StatusCode
+		}
+
+		data = v.Body
+	case resTypes.Redirect:
+		if err == nil {
+			r.redirect(v)
+
+			return
+		}
+
+		data = nil
+	}
+
+	if err == nil {
+		switch v := data.(type) {
+		case resTypes.Stream:
+			r.stream(v, statusCode)
+
+			return
+		case resTypes.SSE:
+			r.sse(v)
+
+			return
+		}
+	}
+
+	var (
+		resp        interface{}
+		contentType = contentTypeJSON
+	)
 
-	var resp interface{}
 	switch v := data.(type) {
 	case resTypes.Raw:
-		resp = v.Data
+		// raw bodies, like the OpenAPI document, are always JSON, whatever the media type accepted by the client.
+		resp = encodeJSON(v.Data)
 	case resTypes.File:
 		r.w.Header().Set("Content-Type", v.ContentType)
 		r.w.WriteHeader(statusCode)
 
-		_, _ = r.w.Write(v.Content)
+		if r.method != http.MethodHead {
+			_, _ = r.w.Write(v.Content)
+		}
 
 		return
 	default:
-		resp = response{
-			Data:  v,
-			Error: errorObj,
+		var respErr *ResponseError
+		if err != nil {
+			respErr = &ResponseError{Err: err, StatusCode: statusCode, Details: errorObj}
+		}
+
+		if _, _, links, ok := pagination(r.req, v); ok && err == nil && len(links) > 0 {
+			r.w.Header().Set("Link", linkHeader(links))
 		}
+
+		envelope := r.envelope
+		if envelope == nil {
+			envelope = DefaultEnvelope
+		}
+
+		contentType, resp = envelope(r.req, v, respErr)
+	}
+
+	// a body which is already marshaled, by the envelope or as raw JSON, is written as it is.
+	body, ok := resp.([]byte)
+	if !ok {
+		contentType, body = r.encode(resp, contentType)
 	}
 
-	r.w.Header().Set("Content-Type", "application/json")
+	r.w.Header().Set("Content-Type", contentType)
 
 	r.w.WriteHeader(statusCode)
 
-	_ = json.NewEncoder(r.w).Encode(resp)
+	// responses to HEAD requests only carry the headers of the response.
+	if r.method == http.MethodHead {
+		return
+	}
+
+	_, _ = r.w.Write(body)
+}
+
+func (r Responder) setHeaders(headers map[string]string, cookies []*http.Cookie) {
+	for k, v := range headers {
+		r.w.Header().Set(k, v)
+	}
+
+	for _, c := range cookies {
+		http.SetCookie(r.w, c)
+	}
+}
+
+func (r Responder) redirect(v resTypes.Redirect) {
+	statusCode := v.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusFound
+	}
+
+	r.setHeaders(nil, v.Cookies)
+
+	http.Redirect(r.w, r.req, v.URL, statusCode)
+}
+
+// encode encodes v in the media type accepted by the client, returning the Content-Type of the response along with
+// its body. contentType is the Content-Type set by the envelope for JSON.
+func (r Responder) encode(v interface{}, contentType string) (string, []byte) {
+	if mediaType, ok := negotiate(r.req.Header.Get("Accept"), r.mediaTypes); ok {
+		c, found := codecs.get(mediaType)
+
+		if _, isDefaultJSON := c.(jsonCodec); found && !isDefaultJSON {
+			if body, err := c.Marshal(v); err == nil {
+				return responseContentType(mediaType, contentType), body
+			}
+		}
+	}
+
+	// JSON is used when none of the media types of the route is accepted, or when v can not be encoded in the
+	// accepted one, like errors for protobuf.
+	return contentType, encodeJSON(v)
 }
 
-// HTTPStatusFromError maps errors to HTTP status codes.
+func encodeJSON(v interface{}) []byte {
+	var buf bytes.Buffer
+
+	_ = json.NewEncoder(&buf).Encode(v)
+
+	return buf.Bytes()
+}
+
+// HTTPStatusFromError maps errors to HTTP status codes. Errors implementing StatusCodeResponder set the status code
+// themselves and the ones implementing ResponseMarshaller add their details to the error object. Errors wrapping
+// context.DeadlineExceeded are responded to with the status code 504, and the ones of request bodies read past their
+// size limit with 413.
 func (r Responder) HTTPStatusFromError(err error) (status int, errObj interface{}) {
+	status, obj := r.errorDetails(nil, err)
+	if obj == nil {
+		return status, nil
+	}
+
+	return status, obj
+}
+
+// errorDetails returns the status code of the response with data and err along with its error object, which is nil
+// when err is nil. The responses to OPTIONS requests are 204 only without data, as they can describe the route.
+func (r Responder) errorDetails(data interface{}, err error) (status int, errObj map[string]interface{}) {
 	if err == nil {
-		switch r.method {
-		case http.MethodPost:
+		switch {
+		case r.method == http.MethodPost:
 			return http.StatusCreated, nil
-		case http.MethodDelete:
+		case r.method == http.MethodDelete, r.method == http.MethodOptions && data == nil:
 			return http.StatusNoContent, nil
 		default:
 			return http.StatusOK, nil
 		}
 	}
 
-	if errors.Is(err, http.ErrMissingFile) {
-		return http.StatusNotFound, map[string]interface{}{
-			"message": err.Error(),
+	obj := map[string]interface{}{
+		"message": err.Error(),
+	}
+
+	var marshaller ResponseMarshaller
+	if errors.As(err, &marshaller) {
+		for k, v := range marshaller.Response() {
+			// the message of the error object is always the message of the returned error.
+			if k != "message" {
+				obj[k] = v
+			}
 		}
 	}
 
-	return http.StatusInternalServerError, map[string]interface{}{
-		"message": err.Error(),
+	var (
+		statusErr   StatusCodeResponder
+		maxBytesErr *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode(), obj
+	case errors.Is(err, http.ErrMissingFile):
+		return http.StatusNotFound, obj
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge, obj
+	case errors.Is(err, context.DeadlineExceeded):
+		// the deadline of the request passed while waiting for a downstream call, like a query or an HTTP service.
+		return http.StatusGatewayTimeout, obj
+	default:
+		return http.StatusInternalServerError, obj
 	}
 }
 
 // response represents an HTTP response.
 type response struct {
-	Error interface{} `json:"error,omitempty"`
-	Data  interface{} `json:"data,omitempty"`
+	Error interface{} `json:"error,omitempty" xml:"error,omitempty" yaml:"error,omitempty"`
+	Data  interface{} `json:"data,omitempty" xml:"data,omitempty" yaml:"data,omitempty"`
+	Meta  interface{} `json:"meta,omitempty" xml:"meta,omitempty" yaml:"meta,omitempty"`
 }
@@ -1,13 +1,17 @@
 package http
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	resTypes "gofr.dev/pkg/gofr/http/response"
+	"gofr.dev/pkg/gofr/validation"
 )
 
 func TestResponder_Respond(t *testing.T) {
@@ -31,9 +35,28 @@ func TestResponder_Respond(t *testing.T) {
 	}
 }
 
+type rateLimitError struct {
+	retryAfter int
+}
+
+func (rateLimitError) Error() string {
+	return "too many requests"
+}
+
+func (rateLimitError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+func (e rateLimitError) Response() map[string]interface{} {
+	// the message can not be overridden by the details of the error.
+	return map[string]interface{}{"retryAfter": e.retryAfter, "message": "ignored"}
+}
+
 func TestResponder_HTTPStatusFromError(t *testing.T) {
 	r := NewResponder(httptest.NewRecorder(), http.MethodGet)
 
+	validationErr := validation.Error{Fields: []validation.FieldError{{Field: "name", Rule: "required", Reason: "is required"}}}
+
 	tests := []struct {
 		desc       string
 		input      error
@@ -45,6 +68,20 @@ func TestResponder_HTTPStatusFromError(t *testing.T) {
 			"message": http.ErrMissingFile.Error()}},
 		{"internal server error", http.ErrHandlerTimeout, http.StatusInternalServerError,
 			map[string]interface{}{"message": http.ErrHandlerTimeout.Error()}},
+		{"validation error", validationErr, http.StatusBadRequest, map[string]interface{}{
+			"message": "validation failed: name is required", "fields": validationErr.Fields}},
+		{"typed error", ErrorEntityNotFound{Name: "id", Value: "1"}, http.StatusNotFound,
+			map[string]interface{}{"message": "No entity found with id: 1"}},
+		{"wrapped typed error", fmt.Errorf("could not update: %w", ErrorConflict{Message: "version mismatch"}),
+			http.StatusConflict, map[string]interface{}{"message": "could not update: version mismatch"}},
+		{"user defined error", rateLimitError{retryAfter: 30}, http.StatusTooManyRequests,
+			map[string]interface{}{"message": "too many requests", "retryAfter": 30}},
+		{"downstream timeout", fmt.Errorf("query failed: %w", context.DeadlineExceeded), http.StatusGatewayTimeout,
+			map[string]interface{}{"message": "query failed: context deadline exceeded"}},
+		{"wrapped validation error", fmt.Errorf("invalid user: %w", validationErr), http.StatusBadRequest,
+			map[string]interface{}{"message": "invalid user: validation failed: name is required", "fields": validationErr.Fields}},
+		{"body too large", &http.MaxBytesError{Limit: 10}, http.StatusRequestEntityTooLarge,
+			map[string]interface{}{"message": "http: request body too large"}},
 	}
 
 	for i, tc := range tests {
@@ -55,3 +92,195 @@ func TestResponder_HTTPStatusFromError(t *testing.T) {
 		assert.Equal(t, tc.errObj, errObj, "TEST[%d], Failed.\n%s", i, tc.desc)
 	}
 }
+
+func TestResponder_StatusForMethods(t *testing.T) {
+	tests := []struct {
+		method     string
+		statusCode int
+	}{
+		{http.MethodGet, http.StatusOK},
+		{http.MethodHead, http.StatusOK},
+		{http.MethodPost, http.StatusCreated},
+		{http.MethodPut, http.StatusOK},
+		{http.MethodPatch, http.StatusOK},
+		{http.MethodDelete, http.StatusNoContent},
+		{http.MethodOptions, http.StatusNoContent},
+	}
+
+	for i, tc := range tests {
+		statusCode, _ := NewResponder(httptest.NewRecorder(), tc.method).HTTPStatusFromError(nil)
+
+		assert.Equal(t, tc.statusCode, statusCode, "TEST[%d], Failed.\n%s", i, tc.method)
+	}
+}
+
+func TestResponder_RespondOptions(t *testing.T) {
+	tests := []struct {
+		desc       string
+		data       interface{}
+		statusCode int
+	}{
+		{"no data", nil, http.StatusNoContent},
+		{"data describing the route", []string{http.MethodGet, http.MethodPost}, http.StatusOK},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+		r := NewResponder(w, http.MethodOptions).WithRequest(httptest.NewRequest(http.MethodOptions, "/users", http.NoBody))
+
+		r.Respond(tc.data, nil)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_RespondHead(t *testing.T) {
+	tests := []struct {
+		desc        string
+		data        interface{}
+		contentType string
+	}{
+		{"json response", map[string]string{"key": "value"}, "application/json"},
+		{"file response", resTypes.File{Content: []byte("content"), ContentType: "text/plain"}, "text/plain"},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodHead).WithRequest(httptest.NewRequest(http.MethodHead, "/", http.NoBody)).Respond(tc.data, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Empty(t, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_RespondNegotiation(t *testing.T) {
+	tests := []struct {
+		desc        string
+		accept      string
+		mediaTypes  []string
+		data        interface{}
+		err         error
+		envelope    Envelope
+		contentType string
+		body        string
+	}{
+		{"no accept header", "", nil, map[string]int{"id": 1}, nil, nil,
+			"application/json", `{"data":{"id":1}}` + "\n"},
+		{"xml", "application/xml", nil, map[string]int{"id": 1}, nil, nil,
+			"application/xml", "<response><data><id>1</id></data></response>"},
+		{"yaml", "application/yaml", nil, map[string]int{"id": 1}, nil, nil,
+			"application/yaml", "data:\n    id: 1\n"},
+		{"preferred media type of route", "*/*", []string{"application/yaml"}, map[string]int{"id": 1}, nil, nil,
+			"application/yaml", "data:\n    id: 1\n"},
+		{"not accepted falls back to json", "image/png", nil, map[string]int{"id": 1}, nil, nil,
+			"application/json", `{"data":{"id":1}}` + "\n"},
+		{"error for protobuf falls back to json", "application/protobuf", nil, nil, ErrorForbidden{}, nil,
+			"application/json", `{"error":{"message":"Forbidden"}}` + "\n"},
+		{"raw body stays json", "application/xml", nil, resTypes.Raw{Data: map[string]int{"id": 1}}, nil, nil,
+			"application/json", `{"id":1}` + "\n"},
+		{"problem details in xml", "application/xml", nil, nil, ErrorForbidden{}, ProblemDetailsEnvelope(nil),
+			"application/problem+xml", "<response><detail>Forbidden</detail><instance>/users</instance>" +
+				"<status>403</status><title>Forbidden</title><type>about:blank</type></response>"},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+		r.Header.Set("Accept", tc.accept)
+
+		NewResponder(w, r.Method).WithRequest(r).WithMediaTypes(tc.mediaTypes).WithEnvelope(tc.envelope).Respond(tc.data, tc.err)
+
+		assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_RespondResponse(t *testing.T) {
+	tests := []struct {
+		desc       string
+		data       resTypes.Response
+		err        error
+		statusCode int
+		headers    map[string]string
+		body       string
+	}{
+		{"custom status code and headers",
+			resTypes.Response{StatusCode: http.StatusAccepted, Headers: map[string]string{"Location": "/jobs/1"},
+				Body: map[string]string{"status": "queued"}},
+			nil, http.StatusAccepted, map[string]string{"Location": "/jobs/1", "Content-Type": "application/json"},
+			`{"data":{"status":"queued"}}`},
+		{"status code of the method",
+			resTypes.Response{Headers: map[string]string{"Cache-Control": "no-store"}, Body: "ok"},
+			nil, http.StatusOK, map[string]string{"Cache-Control": "no-store"}, `{"data":"ok"}`},
+		{"raw body", resTypes.Response{StatusCode: http.StatusAccepted, Body: resTypes.Raw{Data: []int{1}}},
+			nil, http.StatusAccepted, nil, `[1]`},
+		{"status code of the error",
+			resTypes.Response{StatusCode: http.StatusAccepted, Headers: map[string]string{"Retry-After": "30"}},
+			ErrorConflict{}, http.StatusConflict, map[string]string{"Retry-After": "30"},
+			`{"error":{"message":"Conflict"}}`},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/", http.NoBody)).Respond(tc.data, tc.err)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.JSONEq(t, tc.body, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+
+		for k, v := range tc.headers {
+			assert.Equal(t, v, w.Header().Get(k), "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
+
+func TestResponder_RespondCookies(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodPost).WithRequest(httptest.NewRequest(http.MethodPost, "/login", http.NoBody)).Respond(resTypes.Response{
+		Cookies: []*http.Cookie{resTypes.NewCookie("session", "abc", time.Hour), resTypes.ExpiredCookie("legacy")},
+	}, nil)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	cookies := res.Cookies()
+
+	if len(cookies) != 2 {
+		t.Fatalf("expected 2 cookies, got %d", len(cookies))
+	}
+
+	assert.Equal(t, "session", cookies[0].Name)
+	assert.Equal(t, "abc", cookies[0].Value)
+	assert.Equal(t, 3600, cookies[0].MaxAge)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+	assert.Equal(t, "legacy", cookies[1].Name)
+	assert.Equal(t, -1, cookies[1].MaxAge)
+}
+
+func TestResponder_RespondRedirect(t *testing.T) {
+	tests := []struct {
+		desc       string
+		redirect   resTypes.Redirect
+		err        error
+		statusCode int
+		location   string
+	}{
+		{"default status code", resTypes.Redirect{URL: "/home"}, nil, http.StatusFound, "/home"},
+		{"permanent redirect", resTypes.Redirect{URL: "https://gofr.dev", StatusCode: http.StatusMovedPermanently}, nil,
+			http.StatusMovedPermanently, "https://gofr.dev"},
+		{"error", resTypes.Redirect{URL: "/home"}, ErrorUnauthorized{}, http.StatusUnauthorized, ""},
+	}
+
+	for i, tc := range tests {
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/login", http.NoBody)).Respond(tc.redirect, tc.err)
+
+		assert.Equal(t, tc.statusCode, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.location, w.Header().Get("Location"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewCookie creates a cookie for the whole site which is only sent over HTTPS and can not be read by scripts,
+// expiring after maxAge or at the end of the session of the browser when maxAge is 0.
+func NewCookie(name, value string, maxAge time.Duration) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ExpiredCookie creates a cookie which deletes the cookie with the given name from the client, like on logout.
+func ExpiredCookie(name string) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,23 @@
+package response
+
+// Paginated is a page of a list paginated with the page or offset query parameters. It is responded to with the
+// items of the page in Data and the details of the page in meta, along with a Link header to the other pages, e.g.
+//
+//	{"data": [...], "meta": {"page": 2, "limit": 20, "offset": 20, "total": 95, "next": "/users?limit=20&page=3"}}
+//
+// Page is the number of the page, starting from 1, when the client requested the page by its number.
+type Paginated struct {
+	Data   interface{}
+	Page   int
+	Limit  int
+	Offset int
+	Total  int
+}
+
+// CursorPaginated is a page of a list paginated with cursors, NextCursor being the cursor of the next page, which is
+// empty for the last page. It is responded to like Paginated, without the total and the links to the previous pages.
+type CursorPaginated struct {
+	Data       interface{}
+	Limit      int
+	NextCursor string
+}
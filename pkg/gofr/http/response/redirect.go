@@ -0,0 +1,11 @@
+package response
+
+import "net/http"
+
+// Redirect redirects the client to URL, which can be relative to the path of the request, with StatusCode which
+// is 302 Found when not set. Cookies, like the session cookie set on login, are set along with the redirect.
+type Redirect struct {
+	URL        string
+	StatusCode int
+	Cookies    []*http.Cookie
+}
@@ -0,0 +1,14 @@
+package response
+
+import "net/http"
+
+// Response is a response with a custom status code, headers and cookies, e.g. 202 Accepted with a Location header.
+// Its Body is the data of the response, which is created by the envelope like the data returned by any handler,
+// unless it is one of the other response types like Raw. The status code of the method of the request is used when
+// StatusCode is not set.
+type Response struct {
+	StatusCode int
+	Headers    map[string]string
+	Cookies    []*http.Cookie
+	Body       interface{}
+}
@@ -0,0 +1,17 @@
+package response
+
+// SSE is a response of Server-Sent Events. Every event received on Events is written and flushed to the client
+// as soon as it is received, and the response ends once Events is closed or the client disconnects.
+type SSE struct {
+	Events <-chan Event
+}
+
+// Event is a Server-Sent Event. Data is written as it is when it is a string or a []byte and encoded
+// as JSON otherwise. The optional fields are omitted when empty. ID and Name must not contain line breaks,
+// the response ending on such an event as it would be read as other fields by the client.
+type Event struct {
+	ID    string
+	Name  string
+	Data  interface{}
+	Retry int // reconnection time for the client in milliseconds.
+}
@@ -0,0 +1,10 @@
+package response
+
+import "io"
+
+// Stream is a response whose body is copied from Reader and flushed to the client as it is read, e.g. for large
+// exports which should not be buffered in memory. Reader is closed once the response is written if it is an io.Closer.
+type Stream struct {
+	Reader      io.Reader
+	ContentType string
+}
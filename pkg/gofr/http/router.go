@@ -1,7 +1,10 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/gorilla/mux"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -10,28 +13,192 @@ import (
 	"gofr.dev/pkg/gofr/http/middleware"
 )
 
+var (
+	errUnknownMiddleware = errors.New("unknown built-in middleware")
+
+	// allMethods are the methods of the routes added for all the methods.
+	allMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions}
+
+	// errPanic is responded with to the requests whose handler panics, hiding the details of the panic from the client.
+	errPanic = errors.New("some unexpected error has occurred")
+)
+
+// BuiltinMiddleware is the name of a middleware added by GoFr to every route.
+type BuiltinMiddleware string
+
+// The built-in middlewares, in the order in which they are applied to a request.
+const (
+	TracerMiddleware   BuiltinMiddleware = "tracer"
+	LoggingMiddleware  BuiltinMiddleware = "logging"
+	CORSMiddleware     BuiltinMiddleware = "cors"
+	MetricsMiddleware  BuiltinMiddleware = "metrics"
+	RecoveryMiddleware BuiltinMiddleware = "recovery"
+)
+
 // Router is responsible for routing HTTP request.
 type Router struct {
-	mux.Router
+	*mux.Router
+
+	// builtins holds the middleware used for each of the built-in middlewares, nil if it has been disabled.
+	// It is shared by the groups of the router.
+	builtins map[BuiltinMiddleware]func(http.Handler) http.Handler
+
+	// headRoutes holds the path templates having a HEAD route, which are not answered by their GET route.
+	headRoutes map[string]bool
+
+	// methods holds the methods of the routes added using Add, all the standard methods for the routes added for
+	// all of them. It is shared by the groups of the router.
+	methods map[*mux.Route][]string
+
+	// envelope creates the body of the responses to the panicking requests, the default one being used when it is nil.
+	envelope Envelope
 }
 
 // NewRouter creates a new Router instance.
 func NewRouter(c *container.Container) *Router {
 	muxRouter := mux.NewRouter().StrictSlash(false)
+
+	rou := &Router{
+		Router: muxRouter,
+		builtins: map[BuiltinMiddleware]func(http.Handler) http.Handler{
+			TracerMiddleware:  middleware.Tracer,
+			LoggingMiddleware: middleware.Logging(c.Logger),
+			MetricsMiddleware: middleware.Metrics(c.Metrics()),
+		},
+		headRoutes: make(map[string]bool),
+		methods:    make(map[*mux.Route][]string),
+	}
+
+	rou.builtins[CORSMiddleware] = middleware.CORSWithConfig(middleware.CORSConfig{RouteMethods: rou.RouteMethods})
+	rou.builtins[RecoveryMiddleware] = middleware.Recovery(c.Logger, c.Metrics(), rou.respondPanic)
+
+	// the built-ins are looked up for every request, so that they can be replaced after the router is created
+	// while still running before the middlewares added using Use.
 	muxRouter.Use(
-		middleware.Tracer,
-		middleware.Logging(c.Logger),
-		middleware.CORS(),
-		middleware.Metrics(c.Metrics()),
+		rou.builtin(TracerMiddleware),
+		rou.builtin(LoggingMiddleware),
+		rou.builtin(CORSMiddleware),
+		rou.builtin(MetricsMiddleware),
+		rou.builtin(RecoveryMiddleware),
 	)
 
-	return &Router{
-		Router: *muxRouter,
-	}
+	return rou
 }
 
 // Add adds a new route with the given HTTP method, pattern, and handler, wrapping the handler with OpenTelemetry instrumentation.
+// An empty method matches the requests of all methods. HEAD requests are answered by the GET route of a pattern,
+// unless a HEAD route is added for the pattern as well.
 func (rou *Router) Add(method, pattern string, handler http.Handler) {
 	h := otelhttp.NewHandler(handler, "gofr-router")
-	rou.Router.NewRoute().Methods(method).Path(pattern).Handler(h)
+	route := rou.Router.NewRoute().Path(pattern).Handler(h)
+
+	// the template includes the prefixes of the groups, hence it identifies the pattern across all the groups.
+	template, _ := route.GetPathTemplate()
+
+	switch method {
+	case "":
+		// the route matches all the methods as no method matcher is added.
+		rou.methods[route] = allMethods
+	case http.MethodGet:
+		route.Methods(http.MethodGet, http.MethodHead).MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool {
+			return r.Method != http.MethodHead || !rou.headRoutes[template]
+		})
+
+		rou.methods[route] = []string{http.MethodGet, http.MethodHead}
+	case http.MethodHead:
+		rou.headRoutes[template] = true
+
+		route.Methods(method)
+
+		rou.methods[route] = []string{method}
+	default:
+		route.Methods(method)
+
+		rou.methods[route] = []string{method}
+	}
+}
+
+// RouteMethods returns the methods of the routes whose path matches the path of r, whatever the method of r. It is
+// used to answer the CORS preflight requests with the methods allowed by a path.
+func (rou *Router) RouteMethods(r *http.Request) []string {
+	var methods []string
+
+	_ = rou.Router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		routeMethods, ok := rou.methods[route]
+		if !ok {
+			return nil
+		}
+
+		var match mux.RouteMatch
+		if !route.Match(r, &match) && !errors.Is(match.MatchErr, mux.ErrMethodMismatch) {
+			return nil
+		}
+
+		for _, m := range routeMethods {
+			if !slices.Contains(methods, m) {
+				methods = append(methods, m)
+			}
+		}
+
+		return nil
+	})
+
+	return methods
+}
+
+// Group creates a Router for the routes starting with the given path prefix. Middlewares added to the returned
+// Router are only applied to the routes of the group, while the middlewares of the parent Router apply to all of them.
+func (rou *Router) Group(prefix string) *Router {
+	return &Router{
+		Router:     rou.Router.PathPrefix(prefix).Subrouter(),
+		builtins:   rou.builtins,
+		headRoutes: rou.headRoutes,
+		methods:    rou.methods,
+	}
+}
+
+// SetEnvelope sets the Envelope of the responses to the requests whose handler panics, which are responded to with
+// the status code 500.
+func (rou *Router) SetEnvelope(e Envelope) {
+	rou.envelope = e
+}
+
+func (rou *Router) respondPanic(w http.ResponseWriter, r *http.Request) {
+	responder := NewResponder(w, r.Method).WithRequest(r)
+	if rou.envelope != nil {
+		responder.WithEnvelope(rou.envelope)
+	}
+
+	responder.Respond(nil, errPanic)
+}
+
+// UseMiddleware adds middlewares which run after the built-in middlewares, in the order in which they are added.
+func (rou *Router) UseMiddleware(middlewares ...func(http.Handler) http.Handler) {
+	for _, m := range middlewares {
+		rou.Router.Use(m)
+	}
+}
+
+// ReplaceBuiltin replaces the built-in middleware with the given name, keeping its position in the chain.
+// A nil middleware disables it.
+func (rou *Router) ReplaceBuiltin(name BuiltinMiddleware, m func(http.Handler) http.Handler) error {
+	if _, ok := rou.builtins[name]; !ok {
+		return fmt.Errorf("%w: %s", errUnknownMiddleware, name)
+	}
+
+	rou.builtins[name] = m
+
+	return nil
+}
+
+func (rou *Router) builtin(name BuiltinMiddleware) mux.MiddlewareFunc {
+	return func(inner http.Handler) http.Handler {
+		m := rou.builtins[name]
+		if m == nil {
+			return inner
+		}
+
+		return m(inner)
+	}
 }
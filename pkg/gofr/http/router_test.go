@@ -41,3 +41,99 @@ func TestRouter(t *testing.T) {
 		t.Errorf("TestRouter Failed! expected log not found: %v", log)
 	}
 }
+
+func TestRouter_MiddlewareOrder(t *testing.T) {
+	var order []string
+
+	record := func(name string) func(http.Handler) http.Handler {
+		return func(inner http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				inner.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	router := NewRouter(container.NewContainer(testutil.NewMockConfig(nil)))
+
+	router.UseMiddleware(record("custom-1"), record("custom-2"))
+
+	err := router.ReplaceBuiltin(LoggingMiddleware, record("logging"))
+	assert.Nil(t, err)
+
+	router.Group("/v1").UseMiddleware(record("group"))
+
+	router.Add(http.MethodGet, "/v1/test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/test", http.NoBody))
+
+	assert.Equal(t, []string{"logging", "custom-1", "custom-2", "handler"}, order)
+}
+
+func TestRouter_ReplaceBuiltin(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		name       BuiltinMiddleware
+		middleware func(http.Handler) http.Handler
+		corsHeader string
+		err        error
+	}{
+		{"default CORS", "", nil, "*", nil},
+		{"replaced CORS", CORSMiddleware, func(inner http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Access-Control-Allow-Origin", "https://gofr.dev")
+				inner.ServeHTTP(w, r)
+			})
+		}, "https://gofr.dev", nil},
+		{"disabled CORS", CORSMiddleware, nil, "", nil},
+		{"unknown middleware", "gzip", nil, "*", errUnknownMiddleware},
+	}
+
+	for i, tc := range testCases {
+		router := NewRouter(container.NewContainer(testutil.NewMockConfig(nil)))
+
+		router.Add(http.MethodGet, "/test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		if tc.name != "" {
+			err := router.ReplaceBuiltin(tc.name, tc.middleware)
+
+			assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+		assert.Equal(t, tc.corsHeader, rec.Header().Get("Access-Control-Allow-Origin"), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestRouter_RouteMethods(t *testing.T) {
+	router := NewRouter(container.NewContainer(testutil.NewMockConfig(nil)))
+	h := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+
+	router.Add(http.MethodGet, "/users", h)
+	router.Add(http.MethodPost, "/users", h)
+	router.Add(http.MethodDelete, "/users/{id}", h)
+	router.Group("/v1").Add(http.MethodPut, "/orders/{id}", h)
+	router.Add("", "/any", h)
+
+	tests := []struct {
+		desc    string
+		path    string
+		methods []string
+	}{
+		{"routes of a path", "/users", []string{http.MethodGet, http.MethodHead, http.MethodPost}},
+		{"route with variables", "/users/1", []string{http.MethodDelete}},
+		{"route of a group", "/v1/orders/1", []string{http.MethodPut}},
+		{"route for all the methods", "/any", allMethods},
+		{"path without routes", "/orders", nil},
+	}
+
+	for i, tc := range tests {
+		methods := router.RouteMethods(httptest.NewRequest(http.MethodOptions, tc.path, http.NoBody))
+
+		assert.Equal(t, tc.methods, methods, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	resTypes "gofr.dev/pkg/gofr/http/response"
+)
+
+const streamBufferSize = 32 * 1024
+
+// errInvalidEventField is returned for the events whose ID or name contain a line break, which would end the field
+// and let the rest of it be read as other fields by the client.
+var errInvalidEventField = errors.New("event ID and name must not contain line breaks")
+
+// stream copies the body of the response from its reader, flushing every chunk to the client till the reader
+// is exhausted or the client disconnects.
+func (r Responder) stream(s resTypes.Stream, statusCode int) {
+	if closer, ok := s.Reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	contentType := s.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	r.w.Header().Set("Content-Type", contentType)
+	r.w.WriteHeader(statusCode)
+
+	if r.method == http.MethodHead || s.Reader == nil {
+		return
+	}
+
+	buf := make([]byte, streamBufferSize)
+
+	for r.req.Context().Err() == nil {
+		n, err := s.Reader.Read(buf)
+		if n > 0 {
+			if _, writeErr := r.w.Write(buf[:n]); writeErr != nil {
+				return
+			}
+
+			r.flush()
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// sse writes the events of the response as they are received, till the events channel is closed
+// or the client disconnects.
+func (r Responder) sse(s resTypes.SSE) {
+	r.w.Header().Set("Content-Type", "text/event-stream")
+	r.w.Header().Set("Cache-Control", "no-cache")
+	r.w.WriteHeader(http.StatusOK)
+	r.flush()
+
+	for {
+		select {
+		case <-r.req.Context().Done():
+			return
+		case event, ok := <-s.Events:
+			if !ok {
+				return
+			}
+
+			if err := writeEvent(r.w, event); err != nil {
+				return
+			}
+
+			r.flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event resTypes.Event) error {
+	if strings.ContainsAny(event.ID, "\r\n") || strings.ContainsAny(event.Name, "\r\n") {
+		return errInvalidEventField
+	}
+
+	var sb strings.Builder
+
+	if event.ID != "" {
+		fmt.Fprintf(&sb, "id: %s\n", event.ID)
+	}
+
+	if event.Name != "" {
+		fmt.Fprintf(&sb, "event: %s\n", event.Name)
+	}
+
+	if event.Retry > 0 {
+		fmt.Fprintf(&sb, "retry: %d\n", event.Retry)
+	}
+
+	var data string
+
+	switch v := event.Data.(type) {
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		data = string(b)
+	}
+
+	// every line of the data is sent as a separate data field, which the client joins back with new lines. Carriage
+	// returns end the lines as well for the client, hence they are normalized to new lines.
+	data = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(data)
+
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&sb, "data: %s\n", line)
+	}
+
+	sb.WriteString("\n")
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+func (r Responder) flush() {
+	if f, ok := r.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	resTypes "gofr.dev/pkg/gofr/http/response"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestResponder_Stream(t *testing.T) {
+	csv := strings.Repeat("id,name\n1,gofr\n", 5000)
+
+	testCases := []struct {
+		desc        string
+		method      string
+		contentType string
+		expType     string
+		expBody     string
+	}{
+		{"stream with content type", http.MethodGet, "text/csv", "text/csv", csv},
+		{"stream without content type", http.MethodGet, "", "application/octet-stream", csv},
+		{"head request", http.MethodHead, "text/csv", "text/csv", ""},
+	}
+
+	for i, tc := range testCases {
+		w := httptest.NewRecorder()
+		reader := &closeRecorder{Reader: strings.NewReader(csv)}
+
+		NewResponder(w, tc.method).WithRequest(httptest.NewRequest(tc.method, "/export", http.NoBody)).
+			Respond(resTypes.Stream{Reader: reader, ContentType: tc.contentType}, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expType, w.Header().Get("Content-Type"), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.expBody, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.True(t, reader.closed, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestResponder_StreamClientDisconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/export", http.NoBody).WithContext(ctx)
+
+	NewResponder(w, req.Method).WithRequest(req).Respond(resTypes.Stream{Reader: strings.NewReader("data")}, nil)
+
+	assert.Empty(t, w.Body.String())
+}
+
+func TestResponder_StreamWithError(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/export", http.NoBody)).
+		Respond(resTypes.Stream{Reader: strings.NewReader("data")}, http.ErrHandlerTimeout)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestResponder_SSE(t *testing.T) {
+	events := make(chan resTypes.Event, 3)
+
+	events <- resTypes.Event{Data: "started"}
+	events <- resTypes.Event{ID: "2", Name: "progress", Retry: 1000, Data: map[string]int{"percent": 50}}
+	events <- resTypes.Event{Data: []byte("line 1\nline 2\r\nline 3\rline 4")}
+
+	close(events)
+
+	w := httptest.NewRecorder()
+
+	NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/events", http.NoBody)).Respond(resTypes.SSE{Events: events}, nil)
+
+	expected := "data: started\n\n" +
+		"id: 2\nevent: progress\nretry: 1000\ndata: {\"percent\":50}\n\n" +
+		"data: line 1\ndata: line 2\ndata: line 3\ndata: line 4\n\n"
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("Connection"), "the Connection header is not allowed on HTTP/2")
+	assert.True(t, w.Flushed)
+	assert.Equal(t, expected, w.Body.String())
+}
+
+func TestResponder_SSEClientDisconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the events channel is never closed, so the response can only end with the client disconnecting.
+	events := make(chan resTypes.Event)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		req := httptest.NewRequest(http.MethodGet, "/events", http.NoBody).WithContext(ctx)
+
+		NewResponder(httptest.NewRecorder(), req.Method).WithRequest(req).Respond(resTypes.SSE{Events: events}, nil)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("SSE response did not end after the client disconnected")
+	}
+}
+
+func TestResponder_SSEInvalidEvent(t *testing.T) {
+	tests := []struct {
+		desc  string
+		event resTypes.Event
+	}{
+		{"data which can not be encoded", resTypes.Event{Data: make(chan int)}},
+		{"line break in the ID", resTypes.Event{ID: "1\ndata: injected", Data: "hello"}},
+		{"carriage return in the name", resTypes.Event{Name: "update\rretry: 1", Data: "hello"}},
+	}
+
+	for i, tc := range tests {
+		events := make(chan resTypes.Event, 2)
+
+		events <- tc.event
+		events <- resTypes.Event{Data: "not written"}
+
+		close(events)
+
+		w := httptest.NewRecorder()
+
+		NewResponder(w, http.MethodGet).WithRequest(httptest.NewRequest(http.MethodGet, "/events", http.NoBody)).
+			Respond(resTypes.SSE{Events: events}, nil)
+
+		assert.Empty(t, w.Body.String(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
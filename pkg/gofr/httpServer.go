@@ -1,6 +1,9 @@
 package gofr
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,28 +12,73 @@ import (
 	gofrHTTP "gofr.dev/pkg/gofr/http"
 )
 
+// defaultIdleTimeout is the time for which the idle keep-alive connections are kept open. No write timeout is set on
+// the server, as it would cut the streaming responses, the handlers being bounded by REQUEST_TIMEOUT instead.
+const defaultIdleTimeout = 2 * time.Minute
+
 type httpServer struct {
 	router *gofrHTTP.Router
 	port   int
+	srv    *http.Server
+
+	// envelope creates the body of the responses of the handlers, the default one being used when it is nil.
+	envelope gofrHTTP.Envelope
+
+	// requestTimeout is the timeout of the routes which do not set their own, none when it is 0.
+	requestTimeout time.Duration
+
+	// cacheStore holds the responses of the routes caching them, in memory or in Redis following HTTP_CACHE_STORE.
+	cacheStore cacheStore
+
+	// webSockets tracks the WebSocket connections, for them to be closed on shutdown.
+	webSockets webSocketConnections
 }
 
-func newHTTPServer(c *container.Container, port int) *httpServer {
+// newHTTPServer creates the HTTP server of the application. The server uses HTTPS when tlsConfig is not nil.
+func newHTTPServer(c *container.Container, port int, tlsConfig *tls.Config) *httpServer {
+	router := gofrHTTP.NewRouter(c)
+
 	return &httpServer{
-		router: gofrHTTP.NewRouter(c),
+		router: router,
 		port:   port,
+		srv: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           router,
+			ReadHeaderTimeout: 5 * time.Second,
+			IdleTimeout:       defaultIdleTimeout,
+			TLSConfig:         tlsConfig,
+		},
 	}
 }
 
 func (s *httpServer) Run(c *container.Container) {
-	var srv *http.Server
+	var err error
+
+	if s.srv.TLSConfig != nil {
+		c.Logf("Starting server with TLS on port: %d", s.port)
+
+		// certificates are provided by the TLS config, hence the files are not passed here.
+		err = s.srv.ListenAndServeTLS("", "")
+	} else {
+		c.Logf("Starting server on port: %d", s.port)
+
+		err = s.srv.ListenAndServe()
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		c.Error(err)
+	}
+}
 
-	c.Logf("Starting server on port: %d", s.port)
+// Shutdown stops the server from accepting new connections and waits for the in-flight requests
+// to complete or for ctx to be done, whichever happens first. The WebSocket connections are then closed with
+// the status 1001 (going away), waiting for their handlers to return.
+func (s *httpServer) Shutdown(ctx context.Context) error {
+	var err error
 
-	srv = &http.Server{
-		Addr:              fmt.Sprintf(":%d", s.port),
-		Handler:           s.router,
-		ReadHeaderTimeout: 5 * time.Second,
+	if s.srv != nil {
+		err = s.srv.Shutdown(ctx)
 	}
 
-	c.Error(srv.ListenAndServe())
+	return errors.Join(err, s.webSockets.shutdown(ctx))
 }
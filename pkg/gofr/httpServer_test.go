@@ -9,28 +9,18 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"gofr.dev/pkg/gofr/container"
-	gofrHTTP "gofr.dev/pkg/gofr/http"
-	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
 )
 
 func TestRun_ServerStartsListening(t *testing.T) {
-	// Create a mock router and add a new route
-	router := &gofrHTTP.Router{}
-	router.Add(http.MethodGet, "/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	c := container.NewContainer(testutil.NewMockConfig(nil))
+
+	// Create an instance of httpServer and add a new route
+	server := newHTTPServer(c, 8080, nil)
+	server.router.Add(http.MethodGet, "/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
-	// Create a mock container
-	c := &container.Container{
-		Logger: logging.NewLogger(logging.INFO),
-	}
-
-	// Create an instance of httpServer
-	server := &httpServer{
-		router: router,
-		port:   8080,
-	}
-
 	// Start the server
 	go server.Run(c)
 
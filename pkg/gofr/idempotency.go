@@ -0,0 +1,56 @@
+package gofr
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+// idempotency creates the Idempotency-Key middleware from the configs, returning nil when it is not enabled:
+//
+//	HTTP_IDEMPOTENCY      true to replay the responses to the retries of the POST and PATCH requests
+//	HTTP_IDEMPOTENCY_TTL  time for which the responses are replayed, 24h by default
+//
+// The responses are stored in Redis, which must be configured. The keys are scoped by the clients, identified by the
+// subject of their JWT, their API key or their IP address following HTTP_TRUSTED_PROXIES.
+func (a *App) idempotency() func(http.Handler) http.Handler {
+	value := a.Config.Get("HTTP_IDEMPOTENCY")
+	if value == "" {
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		a.container.Errorf("invalid value %q for HTTP_IDEMPOTENCY, the Idempotency-Key header is ignored", value)
+
+		return nil
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	if a.container.Redis == nil {
+		a.container.Error("Redis is not configured for HTTP_IDEMPOTENCY, the Idempotency-Key header is ignored")
+
+		return nil
+	}
+
+	cfg := middleware.IdempotencyConfig{
+		Store:          middleware.NewRedisIdempotencyStore(a.container.Redis),
+		TrustedProxies: a.trustedProxies(),
+	}
+
+	if value := a.Config.Get("HTTP_IDEMPOTENCY_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			a.container.Errorf("invalid value %q for HTTP_IDEMPOTENCY_TTL, using default of 24h", value)
+		} else {
+			cfg.TTL = ttl
+		}
+	}
+
+	return middleware.Idempotency(cfg)
+}
@@ -0,0 +1,37 @@
+package gofr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestApp_idempotency(t *testing.T) {
+	tests := []struct {
+		desc    string
+		configs map[string]string
+		redis   bool
+		enabled bool
+	}{
+		{"not configured", nil, true, false},
+		{"enabled", map[string]string{"HTTP_IDEMPOTENCY": "true", "HTTP_IDEMPOTENCY_TTL": "1h"}, true, true},
+		{"disabled", map[string]string{"HTTP_IDEMPOTENCY": "false"}, true, false},
+		{"invalid value", map[string]string{"HTTP_IDEMPOTENCY": "always"}, true, false},
+		{"invalid TTL", map[string]string{"HTTP_IDEMPOTENCY": "true", "HTTP_IDEMPOTENCY_TTL": "1 day"}, true, true},
+		{"without redis", map[string]string{"HTTP_IDEMPOTENCY": "true"}, false, false},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(tc.configs)
+
+		if tc.redis {
+			a.container, _ = container.NewMockContainer(t)
+		}
+
+		assert.Equal(t, tc.enabled, a.idempotency() != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
@@ -0,0 +1,79 @@
+// Package params parses the parameters of the requests of all the transports, so that they are parsed the same way
+// and return the same errors whether they come from HTTP requests, commands or messages. The errors are exported by
+// the gofr/http package.
+package params
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorInvalidParam is used when the values of parameters of the request are invalid.
+type ErrorInvalidParam struct {
+	Params []string
+}
+
+func (e ErrorInvalidParam) Error() string {
+	return fmt.Sprintf("'%d' invalid parameter(s): %s", len(e.Params), strings.Join(e.Params, ", "))
+}
+
+func (ErrorInvalidParam) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ErrorMissingParam is used when required parameters are missing from the request.
+type ErrorMissingParam struct {
+	Params []string
+}
+
+func (e ErrorMissingParam) Error() string {
+	return fmt.Sprintf("'%d' missing parameter(s): %s", len(e.Params), strings.Join(e.Params, ", "))
+}
+
+func (ErrorMissingParam) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Int parses the value of the parameter key as an int. It returns ErrorMissingParam when the value is empty and
+// ErrorInvalidParam when it is not an int.
+func Int(key, value string) (int, error) {
+	return parse(key, value, strconv.Atoi)
+}
+
+// Float parses the value of the parameter key as a float64, returning the same errors as Int.
+func Float(key, value string) (float64, error) {
+	return parse(key, value, func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	})
+}
+
+// Bool parses the value of the parameter key as a bool, which is one of the values accepted by strconv.ParseBool,
+// returning the same errors as Int.
+func Bool(key, value string) (bool, error) {
+	return parse(key, value, strconv.ParseBool)
+}
+
+// Time parses the value of the parameter key as a time in the RFC 3339 format, returning the same errors as Int.
+func Time(key, value string) (time.Time, error) {
+	return parse(key, value, func(s string) (time.Time, error) {
+		return time.Parse(time.RFC3339, s)
+	})
+}
+
+func parse[T any](key, value string, parse func(string) (T, error)) (T, error) {
+	var zero T
+
+	if value == "" {
+		return zero, ErrorMissingParam{Params: []string{key}}
+	}
+
+	v, err := parse(value)
+	if err != nil {
+		return zero, ErrorInvalidParam{Params: []string{key}}
+	}
+
+	return v, nil
+}
@@ -1,6 +1,8 @@
 package gofr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,24 +13,35 @@ import (
 
 type metricServer struct {
 	port int
+	srv  *http.Server
 }
 
-func newMetricServer(port int) *metricServer {
-	return &metricServer{port: port}
+func newMetricServer(c *container.Container, port int) *metricServer {
+	return &metricServer{
+		port: port,
+		srv: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           metrics.GetHandler(c.Metrics()),
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
 }
 
 func (m *metricServer) Run(c *container.Container) {
-	var srv *http.Server
-
 	if m != nil {
 		c.Logf("Starting metrics server on port: %d", m.port)
 
-		srv = &http.Server{
-			Addr:              fmt.Sprintf(":%d", m.port),
-			Handler:           metrics.GetHandler(c.Metrics()),
-			ReadHeaderTimeout: 5 * time.Second,
+		if err := m.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			c.Error(err)
 		}
+	}
+}
 
-		c.Error(srv.ListenAndServe())
+// Shutdown gracefully stops the metrics server.
+func (m *metricServer) Shutdown(ctx context.Context) error {
+	if m == nil {
+		return nil
 	}
+
+	return m.srv.Shutdown(ctx)
 }
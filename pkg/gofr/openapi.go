@@ -0,0 +1,60 @@
+package gofr
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+	"gofr.dev/pkg/gofr/openapi"
+	"gofr.dev/pkg/gofr/static"
+)
+
+const (
+	openAPIPath   = "/.well-known/openapi.json"
+	swaggerUIPath = "/.well-known/swagger"
+
+	// swaggerAssetPath serves the scripts and styles of Swagger UI, referred to by the page relatively to its path.
+	swaggerAssetPath = "/.well-known/swagger-ui/{name}"
+)
+
+// openAPIHandler serves the OpenAPI document of the HTTP routes of the App, leaving out the default routes.
+func (a *App) openAPIHandler(c *Context) (interface{}, error) {
+	routes := make([]openapi.Route, 0, len(a.routes))
+
+	for _, r := range a.routes {
+		if strings.HasPrefix(r.Path, "/.well-known/") || r.Path == "/favicon.ico" {
+			continue
+		}
+
+		routes = append(routes, r)
+	}
+
+	return response.Raw{Data: openapi.New(c.GetAppName(), c.GetAppVersion(), routes)}, nil
+}
+
+// swaggerUIHandler serves the Swagger UI page rendering the OpenAPI document of the App.
+func swaggerUIHandler(*Context) (interface{}, error) {
+	data, err := static.Files.ReadFile("swagger.html")
+
+	return response.File{
+		Content:     data,
+		ContentType: "text/html; charset=utf-8",
+	}, err
+}
+
+// swaggerAssetHandler serves the scripts and styles of Swagger UI embedded in GoFr.
+func swaggerAssetHandler(c *Context) (interface{}, error) {
+	name := c.PathParam("name")
+
+	data, err := static.Files.ReadFile(path.Join("swagger-ui", name))
+	if err != nil {
+		return nil, gofrHTTP.ErrorEntityNotFound{Name: "file", Value: name}
+	}
+
+	return response.File{
+		Content:     data,
+		ContentType: mime.TypeByExtension(path.Ext(name)),
+	}, nil
+}
@@ -0,0 +1,268 @@
+// Package openapi generates OpenAPI 3 documents describing the HTTP routes of a GoFr application.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Version is the version of the OpenAPI specification the generated documents follow.
+const Version = "3.0.3"
+
+var (
+	// anyMethods are the methods documented for the routes added for all the http methods.
+	anyMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+	// pathParam matches the path parameters of a route pattern, like {id} or {id:[0-9]+}.
+	pathParam = regexp.MustCompile(`{([^{}:]+)(:[^{}]+)?}`)
+)
+
+// Document is an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components *Components         `json:"components,omitempty"`
+}
+
+// Info holds the title and the version of the documented application.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem holds the operations of a path, keyed by their lower case http method.
+type PathItem map[string]*Operation
+
+// Operation describes a single http method of a path.
+type Operation struct {
+	Summary     string                        `json:"summary,omitempty"`
+	Description string                        `json:"description,omitempty"`
+	Tags        []string                      `json:"tags,omitempty"`
+	Parameters  []Parameter                   `json:"parameters,omitempty"`
+	RequestBody *RequestBody                  `json:"requestBody,omitempty"`
+	Responses   map[string]*OperationResponse `json:"responses"`
+}
+
+// Parameter describes a parameter of an operation.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of the requests of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// OperationResponse describes a response of an operation.
+type OperationResponse struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request or response body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas of the named types referred to by the document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Route is a route to be documented, along with the annotations given while adding it.
+type Route struct {
+	// Method is the http method of the route, empty when the route is added for all the methods.
+	Method string
+	// Path is the path template of the route, like /users/{id}.
+	Path string
+
+	Summary     string
+	Description string
+	Tags        []string
+
+	// Request and Response are values of the types of the request and response bodies, if any.
+	Request  interface{}
+	Response interface{}
+
+	// MediaTypes are the media types of the request and response bodies, JSON being documented when it is empty. It
+	// is set by the App from the media types the route is restricted to.
+	MediaTypes []string
+}
+
+// Option annotates a route with the details documented for it, e.g.
+//
+//	app.POST("/users", createUser, openapi.Summary("Create a user"), openapi.Request(User{}), openapi.Response(User{}))
+type Option func(*Route)
+
+// Apply annotates r with the option.
+func (o Option) Apply(r *Route) {
+	o(r)
+}
+
+// Summary sets the short summary of the route.
+func Summary(summary string) Option {
+	return func(r *Route) {
+		r.Summary = summary
+	}
+}
+
+// Description sets the detailed description of the route.
+func Description(description string) Option {
+	return func(r *Route) {
+		r.Description = description
+	}
+}
+
+// Tags sets the tags used to group the route with the related ones.
+func Tags(tags ...string) Option {
+	return func(r *Route) {
+		r.Tags = tags
+	}
+}
+
+// Request sets the type of the request body of the route to the type of v.
+func Request(v interface{}) Option {
+	return func(r *Route) {
+		r.Request = v
+	}
+}
+
+// Response sets the type of the data returned by the handler of the route to the type of v.
+func Response(v interface{}) Option {
+	return func(r *Route) {
+		r.Response = v
+	}
+}
+
+// NewRoute creates the Route for method and path, annotated using the given options.
+func NewRoute(method, path string, options ...Option) Route {
+	r := Route{Method: method, Path: path}
+
+	for _, option := range options {
+		option(&r)
+	}
+
+	return r
+}
+
+// New generates the Document of the given routes. When a path and method is added more than once, the first
+// route is documented, as it is the one serving the requests.
+func New(title, version string, routes []Route) *Document {
+	g := &generator{schemas: make(map[string]*Schema)}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]PathItem),
+	}
+
+	for i := range routes {
+		path, params := parsePath(routes[i].Path)
+
+		methods := anyMethods
+		if routes[i].Method != "" {
+			methods = []string{routes[i].Method}
+		}
+
+		for _, method := range methods {
+			item, ok := doc.Paths[path]
+			if !ok {
+				item = make(PathItem)
+				doc.Paths[path] = item
+			}
+
+			if _, ok := item[strings.ToLower(method)]; ok {
+				continue
+			}
+
+			item[strings.ToLower(method)] = g.operation(method, &routes[i], params)
+		}
+	}
+
+	if len(g.schemas) != 0 {
+		doc.Components = &Components{Schemas: g.schemas}
+	}
+
+	return doc
+}
+
+// parsePath returns the OpenAPI path template of a route pattern, without the patterns of its path parameters,
+// along with the names of the path parameters.
+func parsePath(pattern string) (path string, params []string) {
+	for _, match := range pathParam.FindAllStringSubmatch(pattern, -1) {
+		params = append(params, match[1])
+	}
+
+	return pathParam.ReplaceAllString(pattern, "{$1}"), params
+}
+
+func (g *generator) operation(method string, r *Route, params []string) *Operation {
+	op := &Operation{
+		Summary:     r.Summary,
+		Description: r.Description,
+		Tags:        r.Tags,
+		Responses:   make(map[string]*OperationResponse),
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	if r.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  content(g.schemaOf(r.Request), r.MediaTypes),
+		}
+	}
+
+	// the success status codes are the ones set by the responder of GoFr for each method, OPTIONS being responded to
+	// with 204 only when it returns no data.
+	switch {
+	case method == http.MethodPost:
+		op.Responses["201"] = g.successResponse(r.Response, r.MediaTypes)
+	case method == http.MethodDelete, method == http.MethodOptions && r.Response == nil:
+		op.Responses["204"] = &OperationResponse{Description: "Successful response"}
+	default:
+		op.Responses["200"] = g.successResponse(r.Response, r.MediaTypes)
+	}
+
+	op.Responses["default"] = &OperationResponse{
+		Description: "Error response",
+		Content: content(&Schema{Type: "object", Properties: map[string]*Schema{
+			"error": {Type: "object", Properties: map[string]*Schema{"message": {Type: "string"}}},
+		}}, r.MediaTypes),
+	}
+
+	return op
+}
+
+// successResponse describes the data returned by a handler, which is wrapped in the data field of the response.
+func (g *generator) successResponse(data interface{}, mediaTypes []string) *OperationResponse {
+	resp := &OperationResponse{Description: "Successful response"}
+
+	if data != nil {
+		resp.Content = content(&Schema{Type: "object", Properties: map[string]*Schema{"data": g.schemaOf(data)}}, mediaTypes)
+	}
+
+	return resp
+}
+
+// content describes a body in the given media types, JSON when the route is not restricted to any.
+func content(s *Schema, mediaTypes []string) map[string]MediaType {
+	if len(mediaTypes) == 0 {
+		return map[string]MediaType{"application/json": {Schema: s}}
+	}
+
+	c := make(map[string]MediaType, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		c[mt] = MediaType{Schema: s}
+	}
+
+	return c
+}
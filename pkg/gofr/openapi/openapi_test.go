@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type user struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name,omitempty"`
+	Score     float64           `json:"score"`
+	Active    bool              `json:"active"`
+	Avatar    []byte            `json:"avatar"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]int    `json:"labels"`
+	Address   *address          `json:"address"`
+	Friends   []user            `json:"friends"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Extra     interface{}       `json:"extra"`
+	Secret    string            `json:"-"`
+	internal  string            //nolint:unused // unexported fields are not documented.
+	Metadata  struct{ Key int } `json:"metadata"`
+}
+
+type timestamps struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type document struct {
+	timestamps
+
+	Title string
+}
+
+func TestNew(t *testing.T) {
+	doc := New("sample-api", "v1.0.0", []Route{
+		NewRoute(http.MethodGet, "/users/{id:[0-9]+}", Summary("Get a user"), Description("Returns the user with the id."),
+			Tags("users"), Response(user{})),
+		NewRoute(http.MethodPost, "/users", Request(&user{}), Response(&user{})),
+		NewRoute(http.MethodDelete, "/users/{id}"),
+		// only the first of the routes added for the same path and method is documented.
+		NewRoute(http.MethodGet, "/users/{id}", Summary("shadowed route")),
+	})
+
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Equal(t, Info{Title: "sample-api", Version: "v1.0.0"}, doc.Info)
+	assert.Len(t, doc.Paths, 2)
+
+	get := doc.Paths["/users/{id}"]["get"]
+
+	assert.Equal(t, "Get a user", get.Summary)
+	assert.Equal(t, "Returns the user with the id.", get.Description)
+	assert.Equal(t, []string{"users"}, get.Tags)
+	assert.Equal(t, []Parameter{{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, get.Parameters)
+	assert.Nil(t, get.RequestBody)
+	assert.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{"data": {Ref: "#/components/schemas/user"}}},
+		get.Responses["200"].Content["application/json"].Schema)
+	assert.Contains(t, get.Responses, "default")
+
+	post := doc.Paths["/users"]["post"]
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/user"}, post.RequestBody.Content["application/json"].Schema)
+	assert.Contains(t, post.Responses, "201")
+
+	del := doc.Paths["/users/{id}"]["delete"]
+
+	assert.Equal(t, &OperationResponse{Description: "Successful response"}, del.Responses["204"])
+
+	assert.Equal(t, map[string]*Schema{
+		"address": {Type: "object", Properties: map[string]*Schema{"city": {Type: "string"}}},
+		"user": {Type: "object", Properties: map[string]*Schema{
+			"id":        {Type: "integer", Format: "int64"},
+			"name":      {Type: "string"},
+			"score":     {Type: "number", Format: "double"},
+			"active":    {Type: "boolean"},
+			"avatar":    {Type: "string", Format: "byte"},
+			"tags":      {Type: "array", Items: &Schema{Type: "string"}},
+			"labels":    {Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int32"}},
+			"address":   {Ref: "#/components/schemas/address"},
+			"friends":   {Type: "array", Items: &Schema{Ref: "#/components/schemas/user"}},
+			"createdAt": {Type: "string", Format: "date-time"},
+			"extra":     {},
+			"metadata":  {Type: "object", Properties: map[string]*Schema{"Key": {Type: "integer", Format: "int32"}}},
+		}},
+	}, doc.Components.Schemas)
+}
+
+func TestNew_AnyMethodRoute(t *testing.T) {
+	doc := New("sample-api", "dev", []Route{
+		NewRoute(http.MethodGet, "/files", Summary("List files")),
+		NewRoute("", "/files"),
+	})
+
+	item := doc.Paths["/files"]
+
+	assert.Len(t, item, len(anyMethods))
+	assert.Equal(t, "List files", item["get"].Summary, "the route added for GET first should be documented")
+	assert.Nil(t, doc.Components, "components should be left out when there are no schemas")
+}
+
+func TestNew_OptionsRoute(t *testing.T) {
+	doc := New("sample-api", "dev", []Route{
+		NewRoute(http.MethodOptions, "/users"),
+		NewRoute(http.MethodOptions, "/files", Response([]string{})),
+	})
+
+	// OPTIONS requests are responded to with 204 only when the handler returns no data.
+	assert.Contains(t, doc.Paths["/users"]["options"].Responses, "204")
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}},
+		doc.Paths["/files"]["options"].Responses["200"].Content["application/json"].Schema.Properties["data"])
+}
+
+func TestNew_EmbeddedStruct(t *testing.T) {
+	doc := New("sample-api", "dev", []Route{NewRoute(http.MethodPut, "/documents/{id}", Request(document{}))})
+
+	assert.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{
+		"updatedAt": {Type: "string", Format: "date-time"},
+		"Title":     {Type: "string"},
+	}}, doc.Components.Schemas["document"])
+}
+
+func TestNew_MediaTypes(t *testing.T) {
+	route := NewRoute(http.MethodPost, "/documents", Request(document{}), Response(document{}))
+	route.MediaTypes = []string{"application/xml", "application/yaml"}
+
+	doc := New("sample-api", "dev", []Route{route})
+
+	op := doc.Paths["/documents"]["post"]
+
+	assert.Len(t, op.RequestBody.Content, 2)
+	assert.Contains(t, op.RequestBody.Content, "application/xml")
+	assert.Contains(t, op.Responses["201"].Content, "application/yaml")
+	assert.NotContains(t, op.Responses["default"].Content, "application/json")
+}
+
+func TestDocument_JSON(t *testing.T) {
+	doc := New("sample-api", "dev", []Route{NewRoute(http.MethodGet, "/hello", Response(""))})
+
+	data, err := json.Marshal(doc)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "sample-api", "version": "dev"},
+		"paths": {"/hello": {"get": {"responses": {
+			"200": {"description": "Successful response", "content": {"application/json": {"schema": {
+				"type": "object", "properties": {"data": {"type": "string"}}}}}},
+			"default": {"description": "Error response", "content": {"application/json": {"schema": {
+				"type": "object", "properties": {"error": {"type": "object", "properties": {"message": {"type": "string"}}}}}}}}
+		}}}}
+	}`, string(data))
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema describes the type of a value.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// generator generates the schemas of the request and response types, collecting the schemas of the named
+// struct types so that they are only described once in the components of the document.
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func (g *generator) schemaOf(v interface{}) *Schema {
+	return g.schemaOfType(reflect.TypeOf(v))
+}
+
+//nolint:exhaustive // the remaining kinds, like channels and functions, can not be encoded as JSON.
+func (g *generator) schemaOfType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// byte slices are encoded as base64 strings.
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: g.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// interfaces can hold values of any type.
+		return &Schema{}
+	}
+}
+
+// structSchema returns a reference to the schema of a named struct type, or the schema itself for anonymous ones.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+
+	if _, ok := g.schemas[t.Name()]; ok {
+		return ref
+	}
+
+	// the schema is registered before describing the fields, for the types referring to themselves.
+	s := &Schema{}
+	g.schemas[t.Name()] = s
+
+	*s = *g.objectSchema(t)
+
+	return ref
+}
+
+// objectSchema describes the fields of a struct as they are encoded by encoding/json.
+func (g *generator) objectSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		// the fields of an embedded struct without a json name are encoded as fields of the parent.
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range g.objectSchema(embedded).Properties {
+					s.Properties[k] = v
+				}
+
+				continue
+			}
+
+			if !field.IsExported() {
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = g.schemaOfType(field.Type)
+	}
+
+	return s
+}
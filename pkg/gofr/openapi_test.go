@@ -0,0 +1,124 @@
+package gofr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/openapi"
+)
+
+type product struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+func TestApp_OpenAPIDocument(t *testing.T) {
+	a := newTestApp()
+
+	a.GET("/hello", func(*Context) (interface{}, error) {
+		return helloWorld, nil
+	}, openapi.Summary("Greets the caller"), openapi.Response(""))
+
+	v1 := a.Group("/v1")
+	v1.POST("/orders/{id}", func(*Context) (interface{}, error) {
+		return nil, nil
+	}, openapi.Tags("orders"))
+
+	assert.Nil(t, a.AddRESTHandlers(&product{}))
+
+	// the default routes are added when the App is run.
+	a.add(http.MethodGet, "/.well-known/health", healthHandler)
+	a.add(http.MethodGet, openAPIPath, a.openAPIHandler)
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openAPIPath, http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var doc openapi.Document
+
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("could not decode the OpenAPI document: %v", err)
+	}
+
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Equal(t, openapi.Info{Title: "gofr-app", Version: "dev"}, doc.Info)
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+
+	assert.ElementsMatch(t, []string{"/hello", "/v1/orders/{id}", "/product", "/product/{id}"}, paths)
+
+	assert.Equal(t, "Greets the caller", doc.Paths["/hello"]["get"].Summary)
+	assert.Equal(t, []string{"orders"}, doc.Paths["/v1/orders/{id}"]["post"].Tags)
+
+	// the CRUD routes are derived from the entity.
+	assert.Equal(t, &openapi.Schema{Ref: "#/components/schemas/product"},
+		doc.Paths["/product"]["post"].RequestBody.Content["application/json"].Schema)
+	assert.Equal(t, &openapi.Schema{Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/product"}},
+		doc.Paths["/product"]["get"].Responses["200"].Content["application/json"].Schema.Properties["data"])
+	assert.Equal(t, []string{"product"}, doc.Paths["/product/{id}"]["delete"].Tags)
+	assert.Equal(t, &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{
+		"id":    {Type: "integer", Format: "int32"},
+		"name":  {Type: "string"},
+		"price": {Type: "number", Format: "double"},
+	}}, doc.Components.Schemas["product"])
+}
+
+func TestSwaggerUIHandler(t *testing.T) {
+	a := newTestApp()
+
+	a.add(http.MethodGet, swaggerUIPath, swaggerUIHandler)
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, swaggerUIPath, http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `url: "openapi.json"`)
+}
+
+func TestSwaggerAssetHandler(t *testing.T) {
+	a := newTestApp()
+
+	a.add(http.MethodGet, swaggerUIPath, swaggerUIHandler)
+	a.add(http.MethodGet, swaggerAssetPath, swaggerAssetHandler)
+
+	w := httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, swaggerUIPath, http.NoBody))
+
+	// the scripts and styles are served by the app, not loaded from a CDN.
+	assert.NotContains(t, w.Body.String(), "https://")
+	assert.Contains(t, w.Body.String(), `src="swagger-ui/swagger-ui-bundle.js"`)
+
+	tests := []struct {
+		desc        string
+		name        string
+		contentType string
+	}{
+		{"script", "swagger-ui-bundle.js", "javascript"},
+		{"styles", "swagger-ui.css", "text/css"},
+	}
+
+	for i, tc := range tests {
+		w = httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/swagger-ui/"+tc.name, http.NoBody))
+
+		assert.Equal(t, http.StatusOK, w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Contains(t, w.Header().Get("Content-Type"), tc.contentType, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.NotEmpty(t, w.Body.Bytes(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+
+	w = httptest.NewRecorder()
+	a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/swagger-ui/unknown.js", http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
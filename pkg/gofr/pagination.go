@@ -0,0 +1,119 @@
+package gofr
+
+import (
+	"math"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+const (
+	defaultPageSize    = 20
+	defaultMaxPageSize = 100
+)
+
+// Pagination is the page of a list requested by the client with the query parameters
+//
+//	?page=2&limit=20     by the number of the page, starting from 1
+//	?offset=20&limit=20  by the offset of its first item
+//	?cursor=abc&limit=20 by the cursor returned with the previous page
+//
+// Offset is set for the pages requested by their number as well, so that it can be used in the queries.
+type Pagination struct {
+	Limit  int
+	Offset int
+	Page   int
+	Cursor string
+}
+
+// PaginationOption configures how the pagination of a request is parsed.
+type PaginationOption func(*paginationConfig)
+
+type paginationConfig struct {
+	defaultSize int
+	maxSize     int
+}
+
+// DefaultPageSize sets the limit used when the client does not set it, which is 20 by default.
+func DefaultPageSize(size int) PaginationOption {
+	return func(c *paginationConfig) {
+		c.defaultSize = size
+	}
+}
+
+// MaxPageSize sets the maximum limit which can be requested by the client, which is 100 by default. Greater limits
+// are reduced to it.
+func MaxPageSize(size int) PaginationOption {
+	return func(c *paginationConfig) {
+		c.maxSize = size
+	}
+}
+
+// Pagination parses the page of a list requested by the client. It returns gofrHTTP.ErrorInvalidParam, which is
+// responded to with the status code 400, when the page, limit or offset is not a positive number, or when the offset
+// of the page is too large to be represented.
+func (c *Context) Pagination(options ...PaginationOption) (Pagination, error) {
+	cfg := paginationConfig{defaultSize: defaultPageSize, maxSize: defaultMaxPageSize}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	p := Pagination{Limit: cfg.defaultSize, Cursor: c.Param("cursor")}
+
+	var invalid []string
+
+	if c.Param("limit") != "" {
+		limit, err := c.ParamInt("limit")
+		if err != nil || limit < 1 {
+			invalid = append(invalid, "limit")
+		}
+
+		p.Limit = limit
+	}
+
+	p.Limit = min(p.Limit, cfg.maxSize)
+
+	if c.Param("page") != "" {
+		page, err := c.ParamInt("page")
+		if err != nil || page < 1 {
+			invalid = append(invalid, "page")
+		}
+
+		p.Page = page
+	}
+
+	if c.Param("offset") != "" {
+		offset, err := c.ParamInt("offset")
+		if err != nil || offset < 0 {
+			invalid = append(invalid, "offset")
+		}
+
+		p.Offset = offset
+	}
+
+	// the pages whose offset would overflow can not be requested.
+	if p.Page > 0 && p.Limit > 0 && p.Page-1 > math.MaxInt/p.Limit {
+		invalid = append(invalid, "page")
+	}
+
+	if len(invalid) > 0 {
+		return Pagination{}, gofrHTTP.ErrorInvalidParam{Params: invalid}
+	}
+
+	if p.Page > 0 {
+		p.Offset = (p.Page - 1) * p.Limit
+	}
+
+	return p, nil
+}
+
+// Response returns the response of the page with its items and the total number of items of the list.
+func (p Pagination) Response(items interface{}, total int) response.Paginated {
+	return response.Paginated{Data: items, Page: p.Page, Limit: p.Limit, Offset: p.Offset, Total: total}
+}
+
+// CursorResponse returns the response of the page with its items and the cursor of the next page, which is empty for
+// the last page.
+func (p Pagination) CursorResponse(items interface{}, nextCursor string) response.CursorPaginated {
+	return response.CursorPaginated{Data: items, Limit: p.Limit, NextCursor: nextCursor}
+}
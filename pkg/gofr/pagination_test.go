@@ -0,0 +1,57 @@
+package gofr
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+	"gofr.dev/pkg/gofr/http/response"
+)
+
+func TestContext_Pagination(t *testing.T) {
+	tests := []struct {
+		desc       string
+		query      string
+		options    []PaginationOption
+		pagination Pagination
+		err        error
+	}{
+		{"defaults", "", nil, Pagination{Limit: 20}, nil},
+		{"page", "?page=3&limit=10", nil, Pagination{Page: 3, Limit: 10, Offset: 20}, nil},
+		{"offset", "?offset=15&limit=5", nil, Pagination{Limit: 5, Offset: 15}, nil},
+		{"cursor", "?cursor=abc", nil, Pagination{Limit: 20, Cursor: "abc"}, nil},
+		{"limit reduced to the maximum", "?limit=500", nil, Pagination{Limit: 100}, nil},
+		{"configured sizes", "?limit=500", []PaginationOption{DefaultPageSize(50), MaxPageSize(200)},
+			Pagination{Limit: 200}, nil},
+		{"configured default size", "", []PaginationOption{DefaultPageSize(50)}, Pagination{Limit: 50}, nil},
+		{"invalid params", "?page=0&limit=ten&offset=-1", nil, Pagination{},
+			gofrHTTP.ErrorInvalidParam{Params: []string{"limit", "page", "offset"}}},
+		{"offset of the page overflowing", "?page=" + strconv.Itoa(math.MaxInt/10+2) + "&limit=10", nil, Pagination{},
+			gofrHTTP.ErrorInvalidParam{Params: []string{"page"}}},
+		{"offset of the last page not overflowing", "?page=" + strconv.Itoa(math.MaxInt/10+1) + "&limit=10", nil,
+			Pagination{Page: math.MaxInt/10 + 1, Limit: 10, Offset: math.MaxInt / 10 * 10}, nil},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users"+tc.query, http.NoBody)
+		ctx := newContext(nil, gofrHTTP.NewRequest(req), nil)
+
+		p, err := ctx.Pagination(tc.options...)
+
+		assert.Equal(t, tc.pagination, p, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.err, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestPagination_Response(t *testing.T) {
+	p := Pagination{Page: 2, Limit: 10, Offset: 10}
+
+	assert.Equal(t, response.Paginated{Data: []int{1}, Page: 2, Limit: 10, Offset: 10, Total: 11}, p.Response([]int{1}, 11))
+	assert.Equal(t, response.CursorPaginated{Data: []int{1}, Limit: 10, NextCursor: "next"},
+		p.CursorResponse([]int{1}, "next"))
+}
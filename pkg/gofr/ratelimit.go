@@ -0,0 +1,158 @@
+package gofr
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofr.dev/pkg/gofr/http/middleware"
+)
+
+const defaultRateLimitPeriod = time.Second
+
+// rateLimiter creates the rate limiting middleware from the configs, returning nil when no limit is configured:
+//
+//	RATE_LIMIT_REQUESTS   requests allowed to a client in a period on all the routes
+//	RATE_LIMIT_PERIOD     period of the limits, 1s by default
+//	RATE_LIMIT_ROUTES     limits of routes, like "POST /orders=10/1m,GET /users/{id}=100"
+//	RATE_LIMIT_KEY        key of the clients, one of ip (default), api_key, jwt_subject and route
+//	RATE_LIMIT_STORE      store of the buckets, memory (default) or redis for the limits to hold across replicas
+//
+// The IP addresses of the clients are the ones of the peers of the connections, unless HTTP_TRUSTED_PROXIES is set.
+// The limiter runs before the middlewares of the route groups, so jwt_subject requires OAuth to be enabled on the App,
+// the requests being identified by their IP address otherwise.
+func (a *App) rateLimiter() func(http.Handler) http.Handler {
+	period := defaultRateLimitPeriod
+
+	if value := a.Config.Get("RATE_LIMIT_PERIOD"); value != "" {
+		p, err := time.ParseDuration(value)
+		if err != nil || p < time.Millisecond {
+			a.container.Errorf("invalid value %q for RATE_LIMIT_PERIOD, using default of %v", value, defaultRateLimitPeriod)
+		} else {
+			period = p
+		}
+	}
+
+	cfg := middleware.RateLimitConfig{Routes: a.routeRateLimits(period), TrustedProxies: a.trustedProxies()}
+
+	if value := a.Config.Get("RATE_LIMIT_REQUESTS"); value != "" {
+		requests, err := strconv.Atoi(value)
+		if err != nil || requests <= 0 {
+			a.container.Errorf("invalid value %q for RATE_LIMIT_REQUESTS, the rate limit is not enabled", value)
+		} else {
+			cfg.Limit = middleware.RateLimit{Requests: requests, Period: period}
+		}
+	}
+
+	if cfg.Limit.Requests == 0 && len(cfg.Routes) == 0 {
+		return nil
+	}
+
+	switch key := a.Config.GetOrDefault("RATE_LIMIT_KEY", "ip"); key {
+	case "ip":
+	case "api_key":
+		cfg.Key = middleware.KeyByAPIKey
+	case "jwt_subject":
+		cfg.Key = middleware.KeyByJWTSubject
+	case "route":
+		cfg.Key = middleware.KeyByRoute
+	default:
+		a.container.Errorf("invalid value %q for RATE_LIMIT_KEY, using the IP address of the clients", key)
+	}
+
+	if store := a.Config.GetOrDefault("RATE_LIMIT_STORE", "memory"); store == "redis" {
+		if a.container.Redis != nil {
+			cfg.Store = middleware.NewRedisRateLimitStore(a.container.Redis)
+		} else {
+			a.container.Error("Redis is not configured for RATE_LIMIT_STORE, the rate limits are held in memory")
+		}
+	}
+
+	return middleware.RateLimiter(cfg, a.container.Metrics())
+}
+
+// routeRateLimits parses the limits of RATE_LIMIT_ROUTES, whose periods are the default period when not set.
+func (a *App) routeRateLimits(period time.Duration) map[string]middleware.RateLimit {
+	value := a.Config.Get("RATE_LIMIT_ROUTES")
+	if value == "" {
+		return nil
+	}
+
+	routes := make(map[string]middleware.RateLimit)
+
+	for _, entry := range strings.Split(value, ",") {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			a.container.Errorf("invalid rate limit %q in RATE_LIMIT_ROUTES, it is ignored", entry)
+
+			continue
+		}
+
+		route, limit := strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		requests, routePeriod, _ := strings.Cut(limit, "/")
+
+		l := middleware.RateLimit{Period: period}
+
+		n, err := strconv.Atoi(requests)
+		if err == nil && routePeriod != "" {
+			l.Period, err = time.ParseDuration(routePeriod)
+		}
+
+		if err != nil || n <= 0 || l.Period < time.Millisecond {
+			a.container.Errorf("invalid rate limit %q in RATE_LIMIT_ROUTES, it is ignored", entry)
+
+			continue
+		}
+
+		l.Requests = n
+		routes[route] = l
+	}
+
+	return routes
+}
+
+// trustedProxies parses HTTP_TRUSTED_PROXIES, which is either the number of proxies in front of the app or the comma
+// separated networks of the proxies, like "10.0.0.0/8,192.168.1.10". The X-Forwarded-For header of the requests is only
+// used to find the IP address of the clients when it is set.
+func (a *App) trustedProxies() middleware.TrustedProxies {
+	value := a.Config.Get("HTTP_TRUSTED_PROXIES")
+	if value == "" {
+		return middleware.TrustedProxies{}
+	}
+
+	if count, err := strconv.Atoi(value); err == nil {
+		if count < 0 {
+			a.container.Errorf("invalid value %q for HTTP_TRUSTED_PROXIES, no proxy is trusted", value)
+
+			return middleware.TrustedProxies{}
+		}
+
+		return middleware.TrustedProxies{Count: count}
+	}
+
+	var proxies middleware.TrustedProxies
+
+	for _, entry := range splitConfig(value) {
+		cidr := entry
+
+		// the addresses of single proxies are the networks of their address only.
+		if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+			cidr += "/32"
+		} else if ip != nil {
+			cidr += "/128"
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			a.container.Errorf("invalid proxy %q in HTTP_TRUSTED_PROXIES, it is ignored", entry)
+
+			continue
+		}
+
+		proxies.CIDRs = append(proxies.CIDRs, network)
+	}
+
+	return proxies
+}
@@ -0,0 +1,148 @@
+package gofr
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/http/middleware"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+func TestApp_rateLimiter(t *testing.T) {
+	tests := []struct {
+		desc    string
+		configs map[string]string
+		enabled bool
+	}{
+		{"not configured", nil, false},
+		{"global limit", map[string]string{"RATE_LIMIT_REQUESTS": "10"}, true},
+		{"invalid global limit", map[string]string{"RATE_LIMIT_REQUESTS": "ten"}, false},
+		{"route limits", map[string]string{"RATE_LIMIT_ROUTES": "POST /orders=5/1m", "RATE_LIMIT_KEY": "api_key"}, true},
+		{"invalid route limits", map[string]string{"RATE_LIMIT_ROUTES": "POST /orders,GET /users=0"}, false},
+		{"redis store without redis", map[string]string{"RATE_LIMIT_REQUESTS": "10", "RATE_LIMIT_STORE": "redis"}, true},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(tc.configs)
+
+		assert.Equal(t, tc.enabled, a.rateLimiter() != nil, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_trustedProxies(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+	_, single, _ := net.ParseCIDR("192.168.1.10/32")
+	_, ipv6, _ := net.ParseCIDR("::1/128")
+
+	tests := []struct {
+		desc    string
+		value   string
+		proxies middleware.TrustedProxies
+	}{
+		{"not configured", "", middleware.TrustedProxies{}},
+		{"number of proxies", "2", middleware.TrustedProxies{Count: 2}},
+		{"invalid number of proxies", "-1", middleware.TrustedProxies{}},
+		{"networks of proxies", "10.0.0.0/8, 192.168.1.10, ::1, proxy", middleware.TrustedProxies{
+			CIDRs: []*net.IPNet{network, single, ipv6}}},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(map[string]string{"HTTP_TRUSTED_PROXIES": tc.value})
+
+		assert.Equal(t, tc.proxies, a.trustedProxies(), "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestApp_routeRateLimits(t *testing.T) {
+	a := newTestApp()
+	a.Config = testutil.NewMockConfig(map[string]string{
+		"RATE_LIMIT_ROUTES": "POST /orders=5/1m, GET /users/{id}=100, /files=1/0s, /reports=x",
+	})
+
+	assert.Equal(t, map[string]middleware.RateLimit{
+		"POST /orders":    {Requests: 5, Period: time.Minute},
+		"GET /users/{id}": {Requests: 100, Period: time.Second},
+	}, a.routeRateLimits(time.Second))
+}
+
+func TestApp_RateLimit(t *testing.T) {
+	a := newTestApp()
+	a.Config = testutil.NewMockConfig(map[string]string{"RATE_LIMIT_ROUTES": "GET /hello=1/1m"})
+
+	a.GET("/hello", func(*Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	a.httpServer.router.UseMiddleware(a.rateLimiter())
+
+	tests := []int{http.StatusOK, http.StatusTooManyRequests}
+
+	for i, statusCode := range tests {
+		w := httptest.NewRecorder()
+		a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello", http.NoBody))
+
+		assert.Equal(t, statusCode, w.Code, "TEST[%d], Failed.\n", i)
+	}
+}
+
+// withSubject is a stand-in for the OAuth middleware, setting the claims of the request with the subject of the
+// X-Subject header.
+func withSubject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims := jwt.MapClaims{"sub": r.Header.Get("X-Subject")}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.JWTClaim("JWTClaims"), claims)))
+	})
+}
+
+func TestApp_RateLimitJWTSubject(t *testing.T) {
+	configs := map[string]string{"RATE_LIMIT_REQUESTS": "1", "RATE_LIMIT_PERIOD": "1m", "RATE_LIMIT_KEY": "jwt_subject"}
+
+	tests := []struct {
+		desc     string
+		appAuth  bool
+		statuses []int
+	}{
+		// the requests of each subject use their own bucket.
+		{"OAuth enabled on the app", true, []int{http.StatusOK, http.StatusOK}},
+		// the limiter runs before the middlewares of the groups, hence the requests are identified by their IP address.
+		{"OAuth enabled on a group", false, []int{http.StatusOK, http.StatusTooManyRequests}},
+	}
+
+	for i, tc := range tests {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(configs)
+
+		v1 := a.Group("/v1")
+		v1.GET("/hello", func(*Context) (interface{}, error) {
+			return helloWorld, nil
+		})
+
+		if tc.appAuth {
+			a.UseMiddleware(withSubject)
+		} else {
+			v1.Use(withSubject)
+		}
+
+		a.httpServer.router.UseMiddleware(a.rateLimiter())
+
+		for j, subject := range []string{"alice", "bob"} {
+			req := httptest.NewRequest(http.MethodGet, "/v1/hello", http.NoBody)
+			req.Header.Set("X-Subject", subject)
+
+			w := httptest.NewRecorder()
+			a.httpServer.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.statuses[j], w.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package gofr
 
 import (
 	"context"
+	"time"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
 )
 
 // Request is an interface which is written because it allows us
@@ -13,4 +16,74 @@ type Request interface {
 	PathParam(string) string
 	Bind(interface{}) error
 	HostName() string
+
+	// Params returns all the values of a repeated parameter.
+	Params(string) []string
+
+	// The typed getters of the parameters return an error when a parameter is not set or can not be parsed. For HTTP and
+	// cmd requests these are gofrHTTP.ErrorMissingParam and gofrHTTP.ErrorInvalidParam, so that the handlers can return
+	// them as they are to respond with the status code 400.
+	ParamInt(string) (int, error)
+	ParamFloat(string) (float64, error)
+	ParamBool(string) (bool, error)
+	ParamTime(string) (time.Time, error)
+
+	// Header and Cookie return an empty string when the request does not have them, like the requests of
+	// the transports which do not have headers or cookies.
+	Header(string) string
+	Cookie(string) string
+}
+
+// noopRequest is the Request of the Context given to the application lifecycle hooks,
+// as they are not run in response to any request.
+type noopRequest struct {
+	ctx context.Context
+}
+
+func (r noopRequest) Context() context.Context {
+	return r.ctx
+}
+
+func (noopRequest) Param(string) string {
+	return ""
+}
+
+func (noopRequest) PathParam(string) string {
+	return ""
+}
+
+func (noopRequest) Bind(interface{}) error {
+	return nil
+}
+
+func (noopRequest) HostName() string {
+	return ""
+}
+
+func (noopRequest) Params(string) []string {
+	return nil
+}
+
+func (noopRequest) ParamInt(key string) (int, error) {
+	return gofrHTTP.ParamInt(key, "")
+}
+
+func (noopRequest) ParamFloat(key string) (float64, error) {
+	return gofrHTTP.ParamFloat(key, "")
+}
+
+func (noopRequest) ParamBool(key string) (bool, error) {
+	return gofrHTTP.ParamBool(key, "")
+}
+
+func (noopRequest) ParamTime(key string) (time.Time, error) {
+	return gofrHTTP.ParamTime(key, "")
+}
+
+func (noopRequest) Header(string) string {
+	return ""
+}
+
+func (noopRequest) Cookie(string) string {
+	return ""
 }
@@ -0,0 +1,87 @@
+package gofr
+
+import (
+	"time"
+
+	"gofr.dev/pkg/gofr/openapi"
+)
+
+// RouteOption configures a route while it is added to the App. The options of the openapi package, like
+// openapi.Summary, document the route, while the ones of gofr, like MediaTypes, change how its requests are handled
+// and document it accordingly, e.g.
+//
+//	app.POST("/users", createUser, openapi.Summary("Create a user"), gofr.MediaTypes("application/json"))
+type RouteOption interface {
+	// Apply annotates the OpenAPI documentation of the route.
+	Apply(r *openapi.Route)
+}
+
+// routeConfig holds how the requests of a route are handled, as set by the options of gofr.
+type routeConfig struct {
+	// mediaTypes are the media types the route is restricted to, if any.
+	mediaTypes []string
+
+	// timeout is the timeout of the requests to the route, REQUEST_TIMEOUT being used when it is 0. It is negative
+	// when the route has no timeout.
+	timeout time.Duration
+
+	// cache configures the caching of the responses to the GET and HEAD requests, which is not enabled when it is nil.
+	cache *CacheConfig
+}
+
+// routeOption is a RouteOption of gofr, setting how the requests of the route are handled along with its documentation.
+type routeOption struct {
+	doc    func(r *openapi.Route)
+	handle func(cfg *routeConfig)
+}
+
+func (o routeOption) Apply(r *openapi.Route) {
+	if o.doc != nil {
+		o.doc(r)
+	}
+}
+
+// MediaTypes restricts the route to the given media types, like application/json or application/xml. Requests with
+// a body in another media type are rejected with the status code 415 and the ones accepting none of them with 406.
+// The bodies of the route are documented in these media types.
+func MediaTypes(mediaTypes ...string) RouteOption {
+	return routeOption{
+		doc: func(r *openapi.Route) {
+			r.MediaTypes = mediaTypes
+		},
+		handle: func(cfg *routeConfig) {
+			cfg.mediaTypes = mediaTypes
+		},
+	}
+}
+
+// Timeout sets the timeout of the requests to the route, overriding REQUEST_TIMEOUT. A timeout of 0 disables it,
+// e.g. for the routes streaming their response.
+func Timeout(timeout time.Duration) RouteOption {
+	return routeOption{
+		handle: func(cfg *routeConfig) {
+			cfg.timeout = timeout
+			if timeout <= 0 {
+				cfg.timeout = -1
+			}
+		},
+	}
+}
+
+// newRoute applies the options of the route for method and path, returning its documentation and how its requests are
+// handled.
+func newRoute(method, path string, options []RouteOption) (openapi.Route, routeConfig) {
+	route := openapi.NewRoute(method, path)
+
+	var cfg routeConfig
+
+	for _, option := range options {
+		option.Apply(&route)
+
+		if o, ok := option.(routeOption); ok && o.handle != nil {
+			o.handle(&cfg)
+		}
+	}
+
+	return route, cfg
+}
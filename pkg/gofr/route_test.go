@@ -0,0 +1,36 @@
+package gofr
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/openapi"
+)
+
+func TestNewRoute(t *testing.T) {
+	route, cfg := newRoute(http.MethodPost, "/users", []RouteOption{
+		openapi.Summary("Create a user"),
+		MediaTypes("application/xml"),
+	})
+
+	// the options of the openapi package only document the route, while the ones of gofr also set how it is handled.
+	assert.Equal(t, "Create a user", route.Summary)
+	assert.Equal(t, []string{"application/xml"}, route.MediaTypes)
+	assert.Equal(t, routeConfig{mediaTypes: []string{"application/xml"}}, cfg)
+
+	_, cfg = newRoute(http.MethodGet, "/events", []RouteOption{Timeout(0)})
+
+	assert.Equal(t, routeConfig{timeout: -1}, cfg)
+
+	_, cfg = newRoute(http.MethodGet, "/reports", []RouteOption{Timeout(time.Minute)})
+
+	assert.Equal(t, routeConfig{timeout: time.Minute}, cfg)
+
+	route, cfg = newRoute(http.MethodGet, "/users", nil)
+
+	assert.Equal(t, openapi.Route{Method: http.MethodGet, Path: "/users"}, route)
+	assert.Equal(t, routeConfig{}, cfg)
+}
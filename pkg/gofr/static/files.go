@@ -1,7 +1,12 @@
+// Package static holds the files served by the default routes of GoFr, like the favicon and the Swagger UI page.
+//
+// The scripts and styles of Swagger UI are the ones of the pinned swagger-ui-dist release, vendored in swagger-ui with
+// its license so that the page is rendered without loading anything from a CDN. They are updated using go generate.
 package static
 
 import "embed"
 
-//go:embed *
+//go:generate sh -c "mkdir -p swagger-ui && cd swagger-ui && for f in swagger-ui.css swagger-ui-bundle.js LICENSE; do curl -fsSL -o $f https://unpkg.com/swagger-ui-dist@5.18.2/$f || exit 1; done"
 
+//go:embed favicon.ico swagger.html swagger-ui
 var Files embed.FS
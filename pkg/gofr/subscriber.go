@@ -2,28 +2,64 @@ package gofr
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
 
 	"gofr.dev/pkg/gofr/container"
 )
 
+var errHandlerPanic = errors.New("handler panicked")
+
 type SubscribeFunc func(c *Context) error
 
 type SubscriptionManager struct {
 	container     *container.Container
 	subscriptions map[string]SubscribeFunc
+
+	// cancel stops the subscriber loops and wg tracks them, so that shutdown can wait
+	// for the messages which are being processed.
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
 }
 
 func newSubscriptionManager(c *container.Container) SubscriptionManager {
 	return SubscriptionManager{
 		container:     c,
 		subscriptions: make(map[string]SubscribeFunc),
+		wg:            &sync.WaitGroup{},
+	}
+}
+
+// start runs a subscriber loop for each of the registered topics in its own go-routine.
+func (s *SubscriptionManager) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for topic, handler := range s.subscriptions {
+		s.wg.Add(1)
+
+		go func(topic string, handler SubscribeFunc) {
+			defer s.wg.Done()
+
+			s.startSubscriber(ctx, topic, handler)
+		}(topic, handler)
 	}
 }
 
-func (s *SubscriptionManager) startSubscriber(topic string, handler SubscribeFunc) {
-	// continuously subscribe in an infinite loop
+func (s *SubscriptionManager) startSubscriber(ctx context.Context, topic string, handler SubscribeFunc) {
+	// continuously subscribe in an infinite loop till the subscription is cancelled
 	for {
-		msg, err := s.container.GetSubscriber().Subscribe(context.Background(), topic)
+		select {
+		case <-ctx.Done():
+			s.container.Logger.Infof("stopped subscriber for topic %s", topic)
+
+			return
+		default:
+		}
+
+		msg, err := s.container.GetSubscriber().Subscribe(ctx, topic)
 		if msg == nil {
 			continue
 		}
@@ -33,8 +69,7 @@ func (s *SubscriptionManager) startSubscriber(topic string, handler SubscribeFun
 			continue
 		}
 
-		ctx := newContext(nil, msg, s.container)
-		err = handler(ctx)
+		err = handle(newContext(nil, msg, s.container), handler)
 
 		// commit the message if the subscription function does not return error
 		if err == nil {
@@ -44,3 +79,39 @@ func (s *SubscriptionManager) startSubscriber(topic string, handler SubscribeFun
 		}
 	}
 }
+
+// handle calls the handler with the context of a message, recovering from its panics so that they do not stop the
+// subscriber loop. The panics are returned as errors along with their stack trace, so that the message is not committed.
+func handle(c *Context, handler SubscribeFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v\n%s", errHandlerPanic, r, debug.Stack())
+		}
+	}()
+
+	return handler(c)
+}
+
+// shutdown stops the subscriber loops once the messages being processed are handled. It returns
+// when all the loops have stopped or when ctx is done, whichever happens first.
+func (s *SubscriptionManager) shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+
+	s.cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
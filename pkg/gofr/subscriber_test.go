@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+
 	"gofr.dev/pkg/gofr/container"
 	"gofr.dev/pkg/gofr/datasource"
 	"gofr.dev/pkg/gofr/datasource/pubsub"
@@ -46,6 +48,10 @@ func (s mockSubscriber) Publish(_ context.Context, _ string, _ []byte) error {
 	return nil
 }
 
+func (s mockSubscriber) Close() error {
+	return nil
+}
+
 func (mockSubscriber) Subscribe(_ context.Context, topic string) (*pubsub.Message, error) {
 	if topic == "test-topic" {
 		return &pubsub.Message{
@@ -72,7 +78,7 @@ func TestSubscriptionManager_HandlerError(t *testing.T) {
 
 		// Run the subscriber in a goroutine
 		go func() {
-			subscriptionManager.startSubscriber("test-topic",
+			subscriptionManager.startSubscriber(context.Background(), "test-topic",
 				func(c *Context) error {
 					return handleError("error in test-topic")
 				})
@@ -102,7 +108,7 @@ func TestSubscriptionManager_SubscribeError(t *testing.T) {
 
 		// Run the subscriber in a goroutine
 		go func() {
-			subscriptionManager.startSubscriber("abc",
+			subscriptionManager.startSubscriber(context.Background(), "abc",
 				func(c *Context) error {
 					return handleError("error in abc")
 				})
@@ -119,3 +125,45 @@ func TestSubscriptionManager_SubscribeError(t *testing.T) {
 		t.Error("TestSubscriptionManager_SubscribeError Failed! Missing log message about subscription error")
 	}
 }
+
+func TestSubscriptionManager_HandlerPanic(t *testing.T) {
+	c := newContext(nil, &pubsub.Message{Topic: "test-topic"}, &container.Container{})
+
+	err := handle(c, func(*Context) error {
+		panic("handler failed")
+	})
+
+	assert.ErrorIs(t, err, errHandlerPanic)
+	assert.Contains(t, err.Error(), "handler panicked: handler failed")
+	assert.Contains(t, err.Error(), "runtime/debug.Stack()")
+
+	assert.Equal(t, errHandler, handle(c, func(*Context) error { return errHandler }))
+}
+
+func TestSubscriptionManager_Shutdown(t *testing.T) {
+	mockContainer := container.Container{
+		Logger: logging.NewLogger(logging.FATAL),
+		PubSub: mockSubscriber{},
+	}
+	subscriptionManager := newSubscriptionManager(&mockContainer)
+	subscriptionManager.subscriptions["test-topic"] = func(*Context) error {
+		return handleError("error in test-topic")
+	}
+
+	subscriptionManager.start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := subscriptionManager.shutdown(ctx)
+
+	assert.NoError(t, err, "TestSubscriptionManager_Shutdown Failed!")
+}
+
+func TestSubscriptionManager_ShutdownNotStarted(t *testing.T) {
+	subscriptionManager := newSubscriptionManager(&container.Container{})
+
+	err := subscriptionManager.shutdown(context.Background())
+
+	assert.NoError(t, err, "TestSubscriptionManager_ShutdownNotStarted Failed!")
+}
@@ -0,0 +1,199 @@
+package gofr
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"gofr.dev/pkg/gofr/config"
+	"gofr.dev/pkg/gofr/logging"
+)
+
+var (
+	errNoCertificate = errors.New("TLS certificate is not loaded")
+	errInvalidCA     = errors.New("no valid certificate found in client CA file")
+)
+
+// tlsFiles holds the paths of the files used to configure TLS for a server. TLS is enabled when both the
+// certificate and the key are provided and mutual TLS is enabled when the client CA bundle is provided as well.
+type tlsFiles struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+}
+
+// readTLSFiles reads the TLS configuration of a server, prefix being the name of the server like HTTP or GRPC.
+func readTLSFiles(conf config.Config, prefix string) tlsFiles {
+	return tlsFiles{
+		certFile:     conf.Get(prefix + "_TLS_CERT_FILE"),
+		keyFile:      conf.Get(prefix + "_TLS_KEY_FILE"),
+		clientCAFile: conf.Get(prefix + "_TLS_CLIENT_CA_FILE"),
+	}
+}
+
+func (f tlsFiles) enabled() bool {
+	return f.certFile != "" && f.keyFile != ""
+}
+
+// newTLSConfig creates the TLS configuration of a server using the given files. It returns nil when TLS is not
+// configured. The certificates are loaded on the first handshake and reloaded whenever the files are modified, so
+// that renewed certificates are used without restarting the server. If the files cannot be loaded, handshakes fail
+// instead of the server falling back to plain text.
+func newTLSConfig(files tlsFiles, nextProtos []string, logger logging.Logger) *tls.Config {
+	if !files.enabled() {
+		return nil
+	}
+
+	r := &certReloader{files: files, logger: logger}
+
+	if err := r.reloadIfModified(); err != nil {
+		logger.Errorf("could not load TLS certificates: %v", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     nextProtos,
+		GetCertificate: r.getCertificate,
+	}
+
+	if files.clientCAFile != "" {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+		// the client CA pool is only used from the config returned for each connection, so that it can be reloaded.
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientCAs, err := r.getClientCAs()
+			if err != nil {
+				return nil, err
+			}
+
+			connCfg := cfg.Clone()
+			connCfg.ClientCAs = clientCAs
+
+			return connCfg, nil
+		}
+	}
+
+	return cfg
+}
+
+// certReloader keeps the certificate and the client CA pool loaded from disk, reloading them when the
+// modification time of any of the files changes.
+type certReloader struct {
+	files  tlsFiles
+	logger logging.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if err := r.reloadIfModified(); err != nil {
+		r.logger.Errorf("could not reload TLS certificates: %v", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.cert == nil {
+		return nil, errNoCertificate
+	}
+
+	return r.cert, nil
+}
+
+func (r *certReloader) getClientCAs() (*x509.CertPool, error) {
+	if err := r.reloadIfModified(); err != nil {
+		r.logger.Errorf("could not reload TLS certificates: %v", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.clientCAs == nil {
+		return nil, errInvalidCA
+	}
+
+	return r.clientCAs, nil
+}
+
+// reloadIfModified loads the files again if any of them has been modified since they were last loaded.
+// On failure, the previously loaded certificates are kept.
+func (r *certReloader) reloadIfModified() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	loaded := r.cert != nil && !modTime.After(r.modTime)
+	r.mu.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.files.certFile, r.files.keyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.files.clientCAFile != "" {
+		clientCAs, err = loadCertPool(r.files.clientCAFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTime = modTime
+	r.mu.Unlock()
+
+	r.logger.Infof("loaded TLS certificate from %s", r.files.certFile)
+
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+
+	for _, file := range []string{r.files.certFile, r.files.keyFile, r.files.clientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: %s", errInvalidCA, file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,284 @@
+package gofr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/logging"
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCertificate creates a certificate for the given common name, signed by the parent or self-signed when
+// parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return &testCertificate{cert: cert, key: key}
+}
+
+// write writes the certificate and its key in PEM format to the given directory and returns their paths.
+func (c *testCertificate) write(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig_NotConfigured(t *testing.T) {
+	testCases := []tlsFiles{
+		{},
+		{certFile: "server.crt"},
+		{keyFile: "server.key"},
+	}
+
+	for i, tc := range testCases {
+		cfg := newTLSConfig(tc, nil, logging.NewLogger(logging.INFO))
+
+		assert.Nil(t, cfg, "TEST[%d], Failed.\n", i)
+	}
+}
+
+func TestReadTLSFiles(t *testing.T) {
+	conf := testutil.NewMockConfig(map[string]string{
+		"HTTP_TLS_CERT_FILE":      "server.crt",
+		"HTTP_TLS_KEY_FILE":       "server.key",
+		"HTTP_TLS_CLIENT_CA_FILE": "ca.crt",
+	})
+
+	assert.Equal(t, tlsFiles{certFile: "server.crt", keyFile: "server.key", clientCAFile: "ca.crt"},
+		readTLSFiles(conf, "HTTP"))
+	assert.Equal(t, tlsFiles{}, readTLSFiles(conf, "GRPC"))
+}
+
+func TestNewTLSConfig_MissingFiles(t *testing.T) {
+	var cfg *tls.Config
+
+	logs := testutil.StderrOutputForFunc(func() {
+		cfg = newTLSConfig(tlsFiles{certFile: "missing.crt", keyFile: "missing.key"}, nil,
+			logging.NewLogger(logging.ERROR))
+	})
+
+	if cfg == nil {
+		t.Fatal("TLS config is not created")
+	}
+
+	assert.Contains(t, logs, "could not load TLS certificates")
+
+	_, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	assert.ErrorIs(t, err, errNoCertificate)
+}
+
+func TestNewTLSConfig_ReloadsModifiedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", nil)
+
+	certFile, keyFile := newTestCertificate(t, "server-1", ca).write(t, dir, "server")
+
+	cfg := newTLSConfig(tlsFiles{certFile: certFile, keyFile: keyFile}, nil, logging.NewLogger(logging.ERROR))
+	if cfg == nil {
+		t.Fatal("TLS config is not created")
+	}
+
+	cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "server-1", leaf.Subject.CommonName)
+
+	newTestCertificate(t, "server-2", ca).write(t, dir, "server")
+
+	// make sure the modification time moves forward irrespective of the file system time resolution.
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(certFile, future, future); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cert, err = cfg.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "server-2", leaf.Subject.CommonName)
+}
+
+func TestNewTLSConfig_InvalidClientCA(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := newTestCertificate(t, "server", nil).write(t, dir, "server")
+
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := newTLSConfig(tlsFiles{certFile: certFile, keyFile: keyFile, clientCAFile: caFile}, nil,
+		logging.NewLogger(logging.FATAL))
+	if cfg == nil {
+		t.Fatal("TLS config is not created")
+	}
+
+	_, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	assert.ErrorIs(t, err, errInvalidCA)
+}
+
+func TestHTTPServer_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", nil)
+	caFile, _ := ca.write(t, dir, "ca")
+	certFile, keyFile := newTestCertificate(t, "server", ca).write(t, dir, "server")
+	client := newTestCertificate(t, "billing-service", ca)
+
+	c := container.NewContainer(testutil.NewMockConfig(nil))
+	cfg := newTLSConfig(tlsFiles{certFile: certFile, keyFile: keyFile, clientCAFile: caFile},
+		[]string{"h2", "http/1.1"}, c.Logger)
+
+	s := newHTTPServer(c, 8012, cfg)
+	s.router.Add(http.MethodGet, "/whoami", handler{
+		function: func(c *Context) (interface{}, error) {
+			return c.ClientCertificate().Subject.CommonName, nil
+		},
+		container: c,
+	})
+
+	go s.Run(c)
+
+	defer s.Shutdown(context.Background())
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	clientTLS := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	// without a client certificate the handshake must be rejected.
+	_, err := getWithTLS(t, clientTLS, "https://localhost:8012/whoami")
+	assert.Error(t, err)
+
+	clientTLS.Certificates = []tls.Certificate{{Certificate: [][]byte{client.cert.Raw}, PrivateKey: client.key}}
+
+	body, err := getWithTLS(t, clientTLS, "https://localhost:8012/whoami")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Contains(t, body, "billing-service")
+}
+
+func getWithTLS(t *testing.T, cfg *tls.Config, url string) (string, error) {
+	t.Helper()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	// the server is started in a separate goroutine, so retry till it starts listening.
+	for i := 0; i < 20; i++ {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+
+		resp, err = client.Do(req)
+		if err == nil || !errors.Is(err, syscall.ECONNREFUSED) {
+			break
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+
+	return string(body), err
+}
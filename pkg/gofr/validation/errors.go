@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error is returned when the fields of a struct fail their validation rules.
+type Error struct {
+	Fields []FieldError
+}
+
+// FieldError describes a field failing one of its validation rules.
+type FieldError struct {
+	// Field is the name of the field as encoded in JSON, with the path of the structs it is nested in, like address.city.
+	Field string `json:"field"`
+	// Rule is the name of the rule which failed, like required.
+	Rule string `json:"rule"`
+	// Reason describes why the rule failed, like "must be at most 64 characters long".
+	Reason string `json:"reason"`
+}
+
+func (e Error) Error() string {
+	reasons := make([]string, 0, len(e.Fields))
+
+	for _, f := range e.Fields {
+		reasons = append(reasons, fmt.Sprintf("%s %s", f.Field, f.Reason))
+	}
+
+	return "validation failed: " + strings.Join(reasons, "; ")
+}
+
+// StatusCode returns the status code 400 of the responses to the requests failing their validation.
+func (Error) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Response adds the failing fields to the error object of the response.
+func (e Error) Response() map[string]interface{} {
+	return map[string]interface{}{"fields": e.Fields}
+}
@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	errBadParam        = errors.New("invalid parameter for validation rule")
+	errUnsupportedKind = errors.New("validation rule does not support the type")
+)
+
+type rule func(val reflect.Value, param string) (ok bool, reason string, err error)
+
+var (
+	uuidRegex     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	alphaRegex    = regexp.MustCompile(`^[a-zA-Z]+$`)
+	alphanumRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	numericRegex  = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+
+	builtinRules = map[string]rule{
+		"required": required,
+		"min":      sizeRule("at least", func(size, limit float64) bool { return size >= limit }),
+		"max":      sizeRule("at most", func(size, limit float64) bool { return size <= limit }),
+		"len":      sizeRule("exactly", func(size, limit float64) bool { return size == limit }),
+		"oneof":    oneOf,
+		"email": stringRule("must be a valid email address", func(s string) bool {
+			addr, err := mail.ParseAddress(s)
+
+			return err == nil && addr.Address == s
+		}),
+		"url": stringRule("must be a valid URL", func(s string) bool {
+			u, err := url.ParseRequestURI(s)
+
+			return err == nil && u.Scheme != "" && u.Host != ""
+		}),
+		"uuid":     stringRule("must be a valid UUID", uuidRegex.MatchString),
+		"alpha":    stringRule("must contain only letters", alphaRegex.MatchString),
+		"alphanum": stringRule("must contain only letters and numbers", alphanumRegex.MatchString),
+		"numeric":  stringRule("must be a number", numericRegex.MatchString),
+	}
+)
+
+func required(val reflect.Value, _ string) (ok bool, reason string, err error) {
+	return !isEmpty(val), "is required", nil
+}
+
+// isEmpty reports whether val is empty, which is its zero value or no items for slices and maps.
+func isEmpty(val reflect.Value) bool {
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Map {
+		return val.Len() == 0
+	}
+
+	return val.IsZero()
+}
+
+// sizeRule creates a rule comparing the length of strings, slices, arrays and maps, or the value of numbers,
+// with the parameter of the rule.
+func sizeRule(comparison string, compare func(size, limit float64) bool) rule {
+	return func(val reflect.Value, param string) (ok bool, reason string, err error) {
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return false, "", fmt.Errorf("%w %q", errBadParam, param)
+		}
+
+		val, isNil := indirect(val)
+		if isNil {
+			return true, "", nil
+		}
+
+		var size float64
+
+		//nolint:exhaustive // sizes are only defined for the following kinds.
+		switch val.Kind() {
+		case reflect.String:
+			size = float64(utf8.RuneCountInString(val.String()))
+			reason = fmt.Sprintf("must be %s %s characters long", comparison, param)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			size = float64(val.Len())
+			reason = fmt.Sprintf("must have %s %s items", comparison, param)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			size = float64(val.Int())
+			reason = fmt.Sprintf("must be %s %s", comparison, param)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			size = float64(val.Uint())
+			reason = fmt.Sprintf("must be %s %s", comparison, param)
+		case reflect.Float32, reflect.Float64:
+			size = val.Float()
+			reason = fmt.Sprintf("must be %s %s", comparison, param)
+		default:
+			return false, "", fmt.Errorf("%w %s", errUnsupportedKind, val.Type())
+		}
+
+		return compare(size, limit), reason, nil
+	}
+}
+
+// oneOf checks that the value is one of the space separated values in the parameter of the rule.
+func oneOf(val reflect.Value, param string) (ok bool, reason string, err error) {
+	values := strings.Fields(param)
+	reason = "must be one of " + strings.Join(values, ", ")
+
+	val, isNil := indirect(val)
+	if isNil {
+		return true, reason, nil
+	}
+
+	s := fmt.Sprint(val.Interface())
+
+	for _, v := range values {
+		if s == v {
+			return true, reason, nil
+		}
+	}
+
+	return false, reason, nil
+}
+
+// stringRule creates a rule checking the format of strings. Empty strings are not checked, so that optional
+// fields can be left empty; the required rule can be added to the fields which must be set.
+func stringRule(reason string, valid func(s string) bool) rule {
+	return func(val reflect.Value, _ string) (ok bool, r string, err error) {
+		val, isNil := indirect(val)
+		if isNil {
+			return true, reason, nil
+		}
+
+		if val.Kind() != reflect.String {
+			return false, "", fmt.Errorf("%w %s", errUnsupportedKind, val.Type())
+		}
+
+		return val.String() == "" || valid(val.String()), reason, nil
+	}
+}
+
+// indirect returns the value pointed to by val, reporting whether a nil pointer was found instead.
+func indirect(val reflect.Value) (reflect.Value, bool) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return val, true
+		}
+
+		val = val.Elem()
+	}
+
+	return val, false
+}
@@ -0,0 +1,213 @@
+// Package validation validates structs using the rules declared in their validate tags, e.g.
+//
+//	type User struct {
+//		Name  string `json:"name" validate:"required,max=64"`
+//		Email string `json:"email" validate:"required,email"`
+//		Role  string `json:"role" validate:"omitempty,oneof=admin member"`
+//	}
+//
+// The rules of a field are separated by commas and a rule can take a parameter following an equal sign.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const tagName = "validate"
+
+var errUnknownRule = errors.New("unknown validation rule")
+
+// Func validates value, which is the value of a field or of an element of a field, against the rule it was
+// registered for. param is the parameter of the rule in the tag, empty when the rule has no parameter.
+type Func func(value interface{}, param string) bool
+
+// Validator validates structs using the built-in rules along with the custom ones registered on it.
+// The zero value and the nil Validator only validate the built-in rules.
+type Validator struct {
+	mu     sync.RWMutex
+	custom map[string]Func
+}
+
+// New creates a Validator.
+func New() *Validator {
+	return &Validator{custom: make(map[string]Func)}
+}
+
+// Register adds a custom rule named tag, which is validated by fn. A custom rule takes precedence over
+// a built-in rule with the same name.
+func (v *Validator) Register(tag string, fn Func) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.custom == nil {
+		v.custom = make(map[string]Func)
+	}
+
+	v.custom[tag] = fn
+}
+
+// Validate validates the fields of i, which is a struct or a pointer to a struct, along with the fields of the
+// structs nested in it. It returns an Error listing the fields failing their rules. Values of other types are
+// not validated.
+func (v *Validator) Validate(i interface{}) error {
+	val := reflect.ValueOf(i)
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []FieldError
+
+	if err := v.validateStruct(val, "", &fields); err != nil {
+		return err
+	}
+
+	if len(fields) != 0 {
+		return Error{Fields: fields}
+	}
+
+	return nil
+}
+
+func (v *Validator) validateStruct(val reflect.Value, prefix string, fields *[]FieldError) error {
+	t := val.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// the fields of an embedded struct without a json name are validated as fields of the parent.
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			if err := v.validateNested(val.Field(i), prefix, fields); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field, prefix)
+
+		if err := v.validateField(val.Field(i), name, field.Tag.Get(tagName), fields); err != nil {
+			return err
+		}
+
+		// the fields of nested structs are validated as well, unless the rules of the field are to be skipped.
+		if field.Tag.Get(tagName) != "-" {
+			if err := v.validateNested(val.Field(i), name, fields); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) validateField(val reflect.Value, name, tag string, fields *[]FieldError) error {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		rule, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		if rule == "omitempty" {
+			if isEmpty(val) {
+				return nil
+			}
+
+			continue
+		}
+
+		ok, reason, err := v.check(val, rule, param)
+		if err != nil {
+			return fmt.Errorf("%w: %s on field %s", err, rule, name)
+		}
+
+		if !ok {
+			*fields = append(*fields, FieldError{Field: name, Rule: rule, Reason: reason})
+
+			// the remaining rules are not checked once a rule fails, as their reasons would mostly repeat it.
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// validateNested validates the structs held by val, directly or through pointers, slices, arrays and maps.
+//
+//nolint:exhaustive // only the kinds which can hold structs need to be validated.
+func (v *Validator) validateNested(val reflect.Value, name string, fields *[]FieldError) error {
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+
+		return v.validateNested(val.Elem(), name, fields)
+	case reflect.Struct:
+		return v.validateStruct(val, name, fields)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := v.validateNested(val.Index(i), fmt.Sprintf("%s[%d]", name, i), fields); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			if err := v.validateNested(iter.Value(), fmt.Sprintf("%s[%v]", name, iter.Key()), fields); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) check(val reflect.Value, rule, param string) (ok bool, reason string, err error) {
+	if v != nil {
+		v.mu.RLock()
+		fn, isCustom := v.custom[rule]
+		v.mu.RUnlock()
+
+		if isCustom {
+			return fn(val.Interface(), param), fmt.Sprintf("failed the %s validation", rule), nil
+		}
+	}
+
+	r, ok := builtinRules[rule]
+	if !ok {
+		return false, "", errUnknownRule
+	}
+
+	return r(val, param)
+}
+
+// fieldName returns the name of the field in the errors, which is the name it is encoded with as JSON.
+func fieldName(field reflect.StructField, prefix string) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		name = field.Name
+	}
+
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
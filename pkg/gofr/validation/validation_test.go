@@ -0,0 +1,202 @@
+package validation
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City    string `json:"city" validate:"required"`
+	ZipCode string `json:"zipCode" validate:"omitempty,numeric,len=6"`
+}
+
+type audit struct {
+	CreatedBy string `json:"createdBy" validate:"required"`
+}
+
+type user struct {
+	audit
+
+	Name      string            `json:"name" validate:"required,max=8"`
+	Email     string            `json:"email,omitempty" validate:"required,email"`
+	Age       int               `json:"age" validate:"min=18,max=130"`
+	Role      string            `json:"role" validate:"omitempty,oneof=admin member"`
+	Website   *string           `json:"website" validate:"url"`
+	ID        string            `json:"id" validate:"omitempty,uuid"`
+	Tags      []string          `json:"tags" validate:"required,max=2"`
+	Address   *address          `json:"address" validate:"required"`
+	Addresses []address         `json:"addresses"`
+	Contacts  map[string]string `json:"contacts" validate:"omitempty,min=1"`
+	Score     float64           `validate:"max=10.5"`
+	Nickname  string            `json:"nickname" validate:"alpha"`
+	Handle    string            `json:"handle" validate:"alphanum"`
+	Ignored   address           `json:"ignored" validate:"-"`
+	internal  string            //nolint:unused // unexported fields are not validated.
+}
+
+func validUser() user {
+	website := "https://gofr.dev"
+
+	return user{
+		audit:     audit{CreatedBy: "admin"},
+		Name:      "gofr",
+		Email:     "gofr@gofr.dev",
+		Age:       20,
+		Role:      "admin",
+		Website:   &website,
+		ID:        "0b6ba0d3-58a9-4f2c-8c1c-1b6a0e2d6f3e",
+		Tags:      []string{"go"},
+		Address:   &address{City: "Bengaluru", ZipCode: "560001"},
+		Addresses: []address{{City: "Delhi"}},
+		Score:     9.5,
+		Nickname:  "gopher",
+		Handle:    "gopher42",
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	invalidURL := "not a url"
+
+	testCases := []struct {
+		desc   string
+		modify func(u *user)
+		fields []FieldError
+	}{
+		{"valid struct", func(*user) {}, nil},
+		{"missing required field", func(u *user) { u.Name = "" },
+			[]FieldError{{Field: "name", Rule: "required", Reason: "is required"}}},
+		{"string too long", func(u *user) { u.Name = "gofr-framework" },
+			[]FieldError{{Field: "name", Rule: "max", Reason: "must be at most 8 characters long"}}},
+		{"invalid email", func(u *user) { u.Email = "gofr.dev" },
+			[]FieldError{{Field: "email", Rule: "email", Reason: "must be a valid email address"}}},
+		{"number too small", func(u *user) { u.Age = 10 },
+			[]FieldError{{Field: "age", Rule: "min", Reason: "must be at least 18"}}},
+		{"value not in enum", func(u *user) { u.Role = "owner" },
+			[]FieldError{{Field: "role", Rule: "oneof", Reason: "must be one of admin, member"}}},
+		{"empty optional enum", func(u *user) { u.Role = "" }, nil},
+		{"invalid url through pointer", func(u *user) { u.Website = &invalidURL },
+			[]FieldError{{Field: "website", Rule: "url", Reason: "must be a valid URL"}}},
+		{"nil pointer to optional value", func(u *user) { u.Website = nil }, nil},
+		{"invalid uuid", func(u *user) { u.ID = "123" },
+			[]FieldError{{Field: "id", Rule: "uuid", Reason: "must be a valid UUID"}}},
+		{"empty required slice", func(u *user) { u.Tags = []string{} },
+			[]FieldError{{Field: "tags", Rule: "required", Reason: "is required"}}},
+		{"too many items", func(u *user) { u.Tags = []string{"a", "b", "c"} },
+			[]FieldError{{Field: "tags", Rule: "max", Reason: "must have at most 2 items"}}},
+		{"missing nested struct", func(u *user) { u.Address = nil },
+			[]FieldError{{Field: "address", Rule: "required", Reason: "is required"}}},
+		{"invalid nested field", func(u *user) { u.Address.ZipCode = "56" },
+			[]FieldError{{Field: "address.zipCode", Rule: "len", Reason: "must be exactly 6 characters long"}}},
+		{"invalid field in slice of structs", func(u *user) { u.Addresses = append(u.Addresses, address{}) },
+			[]FieldError{{Field: "addresses[1].city", Rule: "required", Reason: "is required"}}},
+		{"invalid field of embedded struct", func(u *user) { u.CreatedBy = "" },
+			[]FieldError{{Field: "createdBy", Rule: "required", Reason: "is required"}}},
+		{"empty map with omitempty", func(u *user) { u.Contacts = map[string]string{} }, nil},
+		{"float too large", func(u *user) { u.Score = 11 },
+			[]FieldError{{Field: "Score", Rule: "max", Reason: "must be at most 10.5"}}},
+		{"non letters", func(u *user) { u.Nickname = "gopher1" },
+			[]FieldError{{Field: "nickname", Rule: "alpha", Reason: "must contain only letters"}}},
+		{"non alphanumeric", func(u *user) { u.Handle = "go-pher" },
+			[]FieldError{{Field: "handle", Rule: "alphanum", Reason: "must contain only letters and numbers"}}},
+		{"skipped field", func(u *user) { u.Ignored = address{ZipCode: "abc"} }, nil},
+		{"multiple failing fields", func(u *user) { u.Name, u.Age = "", 200 }, []FieldError{
+			{Field: "name", Rule: "required", Reason: "is required"},
+			{Field: "age", Rule: "max", Reason: "must be at most 130"},
+		}},
+	}
+
+	v := New()
+
+	for i, tc := range testCases {
+		u := validUser()
+		tc.modify(&u)
+
+		err := v.Validate(&u)
+
+		if tc.fields == nil {
+			assert.Nil(t, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+
+			continue
+		}
+
+		assert.Equal(t, Error{Fields: tc.fields}, err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestValidator_CustomRule(t *testing.T) {
+	type product struct {
+		SKU   string `json:"sku" validate:"required,sku=PRD"`
+		Price int    `json:"price" validate:"min=1"`
+	}
+
+	v := New()
+
+	v.Register("sku", func(value interface{}, param string) bool {
+		s, ok := value.(string)
+
+		return ok && strings.HasPrefix(s, param+"-")
+	})
+
+	assert.Nil(t, v.Validate(product{SKU: "PRD-101", Price: 10}))
+	assert.Equal(t, Error{Fields: []FieldError{{Field: "sku", Rule: "sku", Reason: "failed the sku validation"}}},
+		v.Validate(&product{SKU: "101", Price: 10}))
+
+	// custom rules take precedence over the built-in rules with the same name.
+	v.Register("min", func(interface{}, string) bool { return true })
+
+	assert.Nil(t, v.Validate(&product{SKU: "PRD-101"}))
+}
+
+func TestValidator_InvalidRules(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		value interface{}
+		err   error
+	}{
+		{"unknown rule", &struct {
+			Name string `validate:"unknown"`
+		}{}, errUnknownRule},
+		{"invalid parameter", &struct {
+			Name string `validate:"max=ten"`
+		}{}, errBadParam},
+		{"unsupported type", &struct {
+			Age int `validate:"email"`
+		}{Age: 1}, errUnsupportedKind},
+		{"size of unsupported type", &struct {
+			Active bool `validate:"min=1"`
+		}{}, errUnsupportedKind},
+	}
+
+	for i, tc := range testCases {
+		err := New().Validate(tc.value)
+
+		assert.ErrorIs(t, err, tc.err, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestValidator_NonStructValues(t *testing.T) {
+	var (
+		v   *Validator
+		ptr *user
+	)
+
+	// the nil Validator only validates the built-in rules.
+	assert.Nil(t, v.Validate(map[string]string{"key": "value"}))
+	assert.Nil(t, v.Validate(ptr))
+	assert.Nil(t, v.Validate("hello"))
+	assert.IsType(t, Error{}, v.Validate(&user{}))
+}
+
+func TestError_Error(t *testing.T) {
+	err := Error{Fields: []FieldError{
+		{Field: "name", Rule: "required", Reason: "is required"},
+		{Field: "age", Rule: "min", Reason: "must be at least 18"},
+	}}
+
+	assert.Equal(t, "validation failed: name is required; age must be at least 18", err.Error())
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode())
+	assert.Equal(t, map[string]interface{}{"fields": err.Fields}, err.Response())
+}
@@ -0,0 +1,200 @@
+package gofr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"gofr.dev/pkg/gofr/container"
+	"gofr.dev/pkg/gofr/websocket"
+)
+
+var errNotWebSocket = errors.New("request is not a WebSocket connection")
+
+// WebSocket adds a Handler for WebSocket connections on a route pattern. The request is upgraded to a WebSocket
+// connection after passing through the middlewares of the app, so authentication applies to it as well.
+// The handler can then use ReadMessage, WriteMessage and Bind on the Context to exchange messages with the client.
+// The connection is closed once the handler returns, after writing the data returned by it, if any.
+func (a *App) WebSocket(pattern string, handler Handler) {
+	a.httpRegistered = true
+	a.httpServer.router.Add(http.MethodGet, pattern, a.newWebSocketHandler(handler))
+}
+
+// WebSocket adds a Handler for WebSocket connections on a route pattern relative to the prefix of the group.
+func (g *RouteGroup) WebSocket(pattern string, handler Handler) {
+	g.app.httpRegistered = true
+	g.router.Add(http.MethodGet, pattern, g.app.newWebSocketHandler(handler))
+}
+
+func (a *App) newWebSocketHandler(h Handler) webSocketHandler {
+	return webSocketHandler{
+		function:  h,
+		container: a.container,
+		upgrader:  websocket.NewUpgrader(a.webSocketPingPeriod()),
+		conns:     &a.httpServer.webSockets,
+	}
+}
+
+// webSocketPingPeriod returns the interval at which pings are sent to keep the WebSocket connections alive.
+func (a *App) webSocketPingPeriod() time.Duration {
+	value := a.Config.Get("WEBSOCKET_PING_PERIOD")
+	if value == "" {
+		return defaultWebSocketPingPeriod
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil || period <= 0 {
+		a.container.Logger.Errorf("invalid value %q for WEBSOCKET_PING_PERIOD, using default of %v", value, defaultWebSocketPingPeriod)
+
+		return defaultWebSocketPingPeriod
+	}
+
+	return period
+}
+
+type webSocketHandler struct {
+	function  Handler
+	container *container.Container
+	upgrader  *websocket.Upgrader
+	conns     *webSocketConnections
+}
+
+func (h webSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r)
+	if err != nil {
+		h.container.Logger.Errorf("could not upgrade to WebSocket: %v", err)
+
+		return
+	}
+
+	if !h.conns.add(conn) {
+		_ = conn.Shutdown()
+
+		return
+	}
+
+	defer h.conns.remove(conn)
+
+	path, _ := mux.CurrentRoute(r).GetPathTemplate()
+	path = strings.TrimSuffix(path, "/")
+
+	h.container.Metrics().IncrementCounter(context.Background(), "app_websocket_connections_total_count", "path", path)
+	h.container.Metrics().DeltaUpDownCounter(context.Background(), "app_websocket_active_connections", 1, "path", path)
+
+	defer h.container.Metrics().DeltaUpDownCounter(context.Background(), "app_websocket_active_connections", -1, "path", path)
+
+	c := newContext(nil, websocket.NewRequest(r, conn), h.container)
+	defer c.Trace("gofr-websocket-handler").End()
+
+	data, err := h.function(c)
+
+	if data != nil && err == nil {
+		err = conn.WriteMessage(data)
+	}
+
+	// a closed connection is the usual way for the handler to end, hence it is not reported to the client.
+	if err != nil && websocket.IsClosed(err) {
+		err = nil
+	}
+
+	if err != nil {
+		h.container.Logger.Errorf("error in WebSocket handler for %s: %v", path, err)
+	}
+
+	_ = conn.Close(err)
+}
+
+// webSocketConnections tracks the open WebSocket connections, which are hijacked from the HTTP server and hence not
+// waited for by its Shutdown.
+type webSocketConnections struct {
+	mu     sync.Mutex
+	conns  map[*websocket.Connection]struct{}
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// add tracks conn until remove is called once its handler returns. It returns false when the connections are being
+// shut down, in which case conn should be closed right away.
+func (s *webSocketConnections) add(conn *websocket.Connection) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	if s.conns == nil {
+		s.conns = make(map[*websocket.Connection]struct{})
+	}
+
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+
+	return true
+}
+
+func (s *webSocketConnections) remove(conn *websocket.Connection) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+
+	s.wg.Done()
+}
+
+// shutdown closes the open connections with the status 1001 (going away) and waits for their handlers to return, or
+// for ctx to be done, whichever happens first.
+func (s *webSocketConnections) shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+
+	for conn := range s.conns {
+		_ = conn.Shutdown()
+	}
+
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// webSocketRequest is implemented by the requests of WebSocket handlers.
+type webSocketRequest interface {
+	Connection() *websocket.Connection
+}
+
+// ReadMessage returns the next message received on the WebSocket connection of the request.
+func (c *Context) ReadMessage() ([]byte, error) {
+	r, ok := c.Request.(webSocketRequest)
+	if !ok {
+		return nil, errNotWebSocket
+	}
+
+	return r.Connection().ReadMessage()
+}
+
+// WriteMessage writes a message to the WebSocket connection of the request. A []byte or a string is
+// written as it is, while any other value is encoded as JSON.
+func (c *Context) WriteMessage(v interface{}) error {
+	r, ok := c.Request.(webSocketRequest)
+	if !ok {
+		return errNotWebSocket
+	}
+
+	return r.Connection().WriteMessage(v)
+}
@@ -0,0 +1,207 @@
+// Package websocket provides the WebSocket connections used by the WebSocket handlers of GoFr.
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	gofrHTTP "gofr.dev/pkg/gofr/http"
+)
+
+const (
+	// writeWait is the time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+
+	// pongWaitFactor is the number of ping intervals allowed for the pong of a ping to be received.
+	pongWaitFactor = 2
+
+	// closeReasonInternalError and closeReasonShutdown are the reasons of the close messages sent on errors and on
+	// shutdown, the reasons being limited to 123 bytes by the protocol.
+	closeReasonInternalError = "internal server error"
+	closeReasonShutdown      = "server shutting down"
+)
+
+// Upgrader upgrades HTTP requests to WebSocket connections which are kept alive using ping and pong messages.
+type Upgrader struct {
+	upgrader     websocket.Upgrader
+	pingInterval time.Duration
+}
+
+// NewUpgrader creates an Upgrader whose connections send a ping to the peer every pingInterval. A connection
+// is closed if no pong is received from the peer for two ping intervals.
+func NewUpgrader(pingInterval time.Duration) *Upgrader {
+	return &Upgrader{pingInterval: pingInterval}
+}
+
+// Upgrade upgrades the HTTP request to a WebSocket connection. On failure, an HTTP error response is
+// written to the client.
+func (u *Upgrader) Upgrade(w http.ResponseWriter, r *http.Request) (*Connection, error) {
+	conn, err := u.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Connection{conn: conn, done: make(chan struct{})}
+
+	pongWait := pongWaitFactor * u.pingInterval
+
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	go c.keepAlive(u.pingInterval)
+
+	return c, nil
+}
+
+// Connection is a WebSocket connection. Messages can be written to it concurrently, while they
+// should only be read from a single go-routine.
+type Connection struct {
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// ReadMessage returns the data of the next text or binary message received on the connection.
+func (c *Connection) ReadMessage() ([]byte, error) {
+	_, data, err := c.conn.ReadMessage()
+
+	return data, err
+}
+
+// Bind reads the next message received on the connection and decodes it as JSON into i.
+func (c *Connection) Bind(i interface{}) error {
+	data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, i)
+}
+
+// WriteMessage writes v to the connection. A []byte or a string is written as a text message as it is,
+// while any other value is encoded as JSON.
+func (c *Connection) WriteMessage(v interface{}) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch m := v.(type) {
+	case []byte:
+		data = m
+	case string:
+		data = []byte(m)
+	default:
+		if data, err = json.Marshal(m); err != nil {
+			return err
+		}
+	}
+
+	return c.write(websocket.TextMessage, data)
+}
+
+// Close sends a close message to the peer and closes the connection. When the connection is ended by an error, the
+// close message carries the status 1011 (internal server error) with a fixed reason, the error not being sent to the
+// peer, so it should be logged by the caller. Closing a closed connection has no effect.
+func (c *Connection) Close(err error) error {
+	if err != nil {
+		return c.close(websocket.CloseInternalServerErr, closeReasonInternalError)
+	}
+
+	return c.close(websocket.CloseNormalClosure, "")
+}
+
+// Shutdown sends a close message with the status 1001 (going away) to the peer and closes the connection, as the
+// server is shutting down. Closing a closed connection has no effect.
+func (c *Connection) Shutdown() error {
+	return c.close(websocket.CloseGoingAway, closeReasonShutdown)
+}
+
+func (c *Connection) close(code int, reason string) error {
+	var closeErr error
+
+	c.closeOnce.Do(func() {
+		close(c.done)
+
+		_ = c.write(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason))
+
+		closeErr = c.conn.Close()
+	})
+
+	return closeErr
+}
+
+// IsClosed reports whether err was returned because the connection has been closed by either of the peers.
+func IsClosed(err error) bool {
+	var closeErr *websocket.CloseError
+
+	return errors.As(err, &closeErr) || errors.Is(err, websocket.ErrCloseSent) || errors.Is(err, net.ErrClosed)
+}
+
+func (c *Connection) write(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// keepAlive sends a ping to the peer every interval until the connection is closed.
+func (c *Connection) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			c.writeMu.Unlock()
+
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Request is the request of a WebSocket handler. Its Bind decodes the next message received on the connection,
+// while the other details are read from the HTTP request which was upgraded.
+type Request struct {
+	*gofrHTTP.Request
+
+	conn *Connection
+}
+
+// NewRequest creates the Request of a WebSocket handler from the upgraded HTTP request and its connection.
+func NewRequest(r *http.Request, conn *Connection) *Request {
+	return &Request{
+		Request: gofrHTTP.NewRequest(r),
+		conn:    conn,
+	}
+}
+
+// Bind decodes the next message received on the connection as JSON into i.
+func (r *Request) Bind(i interface{}) error {
+	return r.conn.Bind(i)
+}
+
+// Connection returns the WebSocket connection of the request.
+func (r *Request) Connection() *Connection {
+	return r.conn
+}
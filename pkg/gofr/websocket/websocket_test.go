@@ -0,0 +1,183 @@
+package websocket
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestConnection starts a server upgrading the requests using the given upgrader and passing the connection
+// to serve. It returns the connection of the client.
+func newTestConnection(t *testing.T, upgrader *Upgrader, serve func(*Connection)) *websocket.Conn {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r)
+		if err != nil {
+			t.Errorf("could not upgrade: %v", err)
+
+			return
+		}
+
+		serve(conn)
+	}))
+
+	t.Cleanup(server.Close)
+
+	conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("could not connect: %v", err)
+	}
+
+	resp.Body.Close()
+
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestConnection_WriteMessage(t *testing.T) {
+	messages := []interface{}{
+		[]byte("bytes"),
+		"string",
+		map[string]int{"count": 1},
+	}
+
+	client := newTestConnection(t, NewUpgrader(time.Minute), func(c *Connection) {
+		for _, m := range messages {
+			_ = c.WriteMessage(m)
+		}
+
+		_ = c.Close(nil)
+	})
+
+	expected := []string{"bytes", "string", `{"count":1}`}
+
+	for i, exp := range expected {
+		messageType, data, err := client.ReadMessage()
+
+		assert.Nil(t, err, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, websocket.TextMessage, messageType, "TEST[%d], Failed.\n", i)
+		assert.Equal(t, exp, string(data), "TEST[%d], Failed.\n", i)
+	}
+
+	_, _, err := client.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "expected a normal closure, got %v", err)
+}
+
+func TestConnection_WriteMessageMarshalError(t *testing.T) {
+	errs := make(chan error, 1)
+
+	newTestConnection(t, NewUpgrader(time.Minute), func(c *Connection) {
+		errs <- c.WriteMessage(make(chan int))
+	})
+
+	assert.NotNil(t, <-errs)
+}
+
+func TestConnection_Bind(t *testing.T) {
+	type message struct {
+		Text string `json:"text"`
+	}
+
+	results := make(chan message, 1)
+	errs := make(chan error, 1)
+
+	client := newTestConnection(t, NewUpgrader(time.Minute), func(c *Connection) {
+		var m message
+
+		errs <- c.Bind(&m)
+		results <- m
+
+		// the peer closing the connection is reported as a closed connection.
+		_, err := c.ReadMessage()
+		errs <- err
+	})
+
+	assert.Nil(t, client.WriteMessage(websocket.TextMessage, []byte(`{"text":"hello"}`)))
+	assert.Nil(t, <-errs)
+	assert.Equal(t, message{Text: "hello"}, <-results)
+
+	assert.Nil(t, client.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+	assert.True(t, IsClosed(<-errs))
+}
+
+func TestConnection_Close(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		close  func(c *Connection) error
+		code   int
+		reason string
+	}{
+		{"error", func(c *Connection) error {
+			return c.Close(errors.New(strings.Repeat("internal details ", 10)))
+		}, websocket.CloseInternalServerErr, "internal server error"},
+		{"shutdown", (*Connection).Shutdown, websocket.CloseGoingAway, "server shutting down"},
+	}
+
+	for i, tc := range testCases {
+		client := newTestConnection(t, NewUpgrader(time.Minute), func(c *Connection) {
+			_ = tc.close(c)
+		})
+
+		_, _, err := client.ReadMessage()
+
+		var closeErr *websocket.CloseError
+
+		assert.ErrorAs(t, err, &closeErr, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.code, closeErr.Code, "TEST[%d], Failed.\n%s", i, tc.desc)
+		assert.Equal(t, tc.reason, closeErr.Text, "TEST[%d], Failed.\n%s", i, tc.desc)
+	}
+}
+
+func TestConnection_KeepAlive(t *testing.T) {
+	pings := make(chan struct{}, 1)
+
+	client := newTestConnection(t, NewUpgrader(50*time.Millisecond), func(c *Connection) {
+		// reading is required for the pongs to be processed.
+		_, _ = c.ReadMessage()
+	})
+
+	client.SetPingHandler(func(string) error {
+		select {
+		case pings <- struct{}{}:
+		default:
+		}
+
+		return nil
+	})
+
+	go func() {
+		_, _, _ = client.ReadMessage()
+	}()
+
+	select {
+	case <-pings:
+	case <-time.After(time.Second):
+		t.Error("no ping received from the server")
+	}
+}
+
+func TestIsClosed(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{&websocket.CloseError{Code: websocket.CloseGoingAway}, true},
+		{websocket.ErrCloseSent, true},
+		{net.ErrClosed, true},
+		{errors.New("write timeout"), false},
+		{nil, false},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.expected, IsClosed(tc.err), "TEST[%d], Failed.\n", i)
+	}
+}
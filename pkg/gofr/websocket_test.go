@@ -0,0 +1,228 @@
+package gofr
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorillaWS "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"gofr.dev/pkg/gofr/testutil"
+)
+
+type chatMessage struct {
+	User string `json:"user"`
+	Text string `json:"text"`
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server, path string, header http.Header) (*gorillaWS.Conn, error) {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + path
+
+	conn, resp, err := gorillaWS.DefaultDialer.Dial(url, header)
+	if resp != nil {
+		resp.Body.Close()
+	}
+
+	return conn, err
+}
+
+func TestApp_WebSocket(t *testing.T) {
+	a := newTestApp()
+
+	a.WebSocket("/ws/chat/{room}", func(c *Context) (interface{}, error) {
+		var msg chatMessage
+
+		if err := c.Bind(&msg); err != nil {
+			return nil, err
+		}
+
+		if err := c.WriteMessage(chatMessage{User: msg.User, Text: c.PathParam("room") + ": " + msg.Text}); err != nil {
+			return nil, err
+		}
+
+		data, err := c.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		// the returned data is written to the connection before it is closed.
+		return "bye " + string(data), nil
+	})
+
+	server := httptest.NewServer(a.httpServer.router)
+	defer server.Close()
+
+	conn, err := dialWebSocket(t, server, "/ws/chat/general", nil)
+	if err != nil {
+		t.Fatalf("could not connect to the WebSocket: %v", err)
+	}
+
+	defer conn.Close()
+
+	assert.Nil(t, conn.WriteJSON(chatMessage{User: "gofr", Text: "hello"}))
+
+	var reply chatMessage
+
+	assert.Nil(t, conn.ReadJSON(&reply))
+	assert.Equal(t, chatMessage{User: "gofr", Text: "general: hello"}, reply)
+
+	assert.Nil(t, conn.WriteMessage(gorillaWS.TextMessage, []byte("gofr")))
+
+	_, data, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	assert.Equal(t, "bye gofr", string(data))
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, gorillaWS.IsCloseError(err, gorillaWS.CloseNormalClosure), "expected a normal closure, got %v", err)
+}
+
+func TestApp_WebSocketHandlerError(t *testing.T) {
+	a := newTestApp()
+
+	a.WebSocket("/ws", func(c *Context) (interface{}, error) {
+		return nil, testutil.CustomError{ErrorMessage: "invalid subscription"}
+	})
+
+	server := httptest.NewServer(a.httpServer.router)
+	defer server.Close()
+
+	conn, err := dialWebSocket(t, server, "/ws", nil)
+	if err != nil {
+		t.Fatalf("could not connect to the WebSocket: %v", err)
+	}
+
+	defer conn.Close()
+
+	_, _, err = conn.ReadMessage()
+
+	var closeErr *gorillaWS.CloseError
+
+	assert.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, gorillaWS.CloseInternalServerErr, closeErr.Code)
+	// the error is logged by the server, instead of being sent to the client.
+	assert.Equal(t, "internal server error", closeErr.Text)
+}
+
+func TestApp_WebSocketShutdown(t *testing.T) {
+	a := newTestApp()
+
+	a.WebSocket("/ws", func(c *Context) (interface{}, error) {
+		_, err := c.ReadMessage()
+
+		return nil, err
+	})
+
+	server := httptest.NewServer(a.httpServer.router)
+	defer server.Close()
+
+	conn, err := dialWebSocket(t, server, "/ws", nil)
+	if err != nil {
+		t.Fatalf("could not connect to the WebSocket: %v", err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the handler returns once its connection is closed, which Shutdown waits for.
+	assert.Nil(t, a.httpServer.Shutdown(ctx))
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, gorillaWS.IsCloseError(err, gorillaWS.CloseGoingAway), "expected a going away closure, got %v", err)
+
+	// the connections opened once the server is shutting down are closed right away.
+	conn, err = dialWebSocket(t, server, "/ws", nil)
+	if err != nil {
+		t.Fatalf("could not connect to the WebSocket: %v", err)
+	}
+
+	defer conn.Close()
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, gorillaWS.IsCloseError(err, gorillaWS.CloseGoingAway), "expected a going away closure, got %v", err)
+}
+
+func TestApp_WebSocketAuthentication(t *testing.T) {
+	a := newTestApp()
+
+	a.EnableBasicAuth("admin", "password")
+
+	a.WebSocket("/ws", func(c *Context) (interface{}, error) {
+		return helloWorld, nil
+	})
+
+	server := httptest.NewServer(a.httpServer.router)
+	defer server.Close()
+
+	// the upgrade request passes through the authentication middleware.
+	_, err := dialWebSocket(t, server, "/ws", nil)
+	assert.ErrorIs(t, err, gorillaWS.ErrBadHandshake)
+
+	header := http.Header{}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:password")))
+
+	conn, err := dialWebSocket(t, server, "/ws", header)
+	if err != nil {
+		t.Fatalf("could not connect to the WebSocket: %v", err)
+	}
+
+	defer conn.Close()
+
+	_, data, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	assert.Equal(t, helloWorld, string(data))
+}
+
+func TestApp_WebSocketUpgradeFailure(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	logs := testutil.StderrOutputForFunc(func() {
+		a := newTestApp()
+
+		a.WebSocket("/ws", func(c *Context) (interface{}, error) {
+			return helloWorld, nil
+		})
+
+		// a request without the upgrade headers cannot be upgraded.
+		a.httpServer.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ws", http.NoBody))
+	})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, logs, "could not upgrade to WebSocket")
+}
+
+func TestContext_WebSocketMethodsWithoutConnection(t *testing.T) {
+	c := newContext(nil, noopRequest{ctx: context.Background()}, newTestApp().container)
+
+	_, err := c.ReadMessage()
+	assert.ErrorIs(t, err, errNotWebSocket)
+
+	assert.ErrorIs(t, c.WriteMessage("hello"), errNotWebSocket)
+}
+
+func TestApp_webSocketPingPeriod(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", defaultWebSocketPingPeriod},
+		{"10s", 10 * time.Second},
+		{"invalid", defaultWebSocketPingPeriod},
+		{"-1s", defaultWebSocketPingPeriod},
+	}
+
+	for i, tc := range testCases {
+		a := newTestApp()
+		a.Config = testutil.NewMockConfig(map[string]string{"WEBSOCKET_PING_PERIOD": tc.value})
+
+		assert.Equal(t, tc.expected, a.webSocketPingPeriod(), "TEST[%d], Failed.\n", i)
+	}
+}